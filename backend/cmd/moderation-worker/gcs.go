@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/rummage/backend/internal/services"
+)
+
+// gcsObjectStore is the ObjectStore backed by Cloud Storage. The client is created
+// once at startup and shared by every event.
+type gcsObjectStore struct {
+	client *storage.Client
+}
+
+func newGCSObjectStore(client *storage.Client) *gcsObjectStore {
+	return &gcsObjectStore{client: client}
+}
+
+func (g *gcsObjectStore) Attrs(ctx context.Context, bucket, name string) (*storage.ObjectAttrs, error) {
+	attrs, err := g.client.Bucket(bucket).Object(name).Attrs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("object attrs: %w", err)
+	}
+	return attrs, nil
+}
+
+func (g *gcsObjectStore) Delete(ctx context.Context, bucket, name string) error {
+	return g.client.Bucket(bucket).Object(name).Delete(ctx)
+}
+
+func (g *gcsObjectStore) SetMetadata(ctx context.Context, bucket, name string, md map[string]string) error {
+	obj := g.client.Bucket(bucket).Object(name)
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		return err
+	}
+	next := map[string]string{}
+	for k, v := range attrs.Metadata {
+		next[k] = v
+	}
+	for k, v := range md {
+		next[k] = v
+	}
+	_, err = obj.Update(ctx, storage.ObjectAttrsToUpdate{Metadata: next})
+	return err
+}
+
+// Promote is services.PromoteObject, so a retry after the pending object is gone
+// returns the token already on the promoted one.
+func (g *gcsObjectStore) Promote(ctx context.Context, bucket string, from string, to string, originalMeta map[string]string, token string) (string, error) {
+	return services.PromoteObject(ctx, g.client.Bucket(bucket), from, to, originalMeta, token)
+}
+
+func newToken() string {
+	// Firebase download token is an arbitrary string; UUID is fine.
+	// Use time-based token to avoid adding new deps.
+	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), os.Getpid())
+}
+
+func firebaseDownloadURL(bucket string, objectName string, token string) string {
+	// https://firebasestorage.googleapis.com/v0/b/{bucket}/o/{path}?alt=media&token={token}
+	return fmt.Sprintf(
+		"https://firebasestorage.googleapis.com/v0/b/%s/o/%s?alt=media&token=%s",
+		bucket,
+		url.PathEscape(objectName),
+		url.QueryEscape(token),
+	)
+}
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+// Eventarc delivers CloudEvents; for GCS finalized events the body contains object info.
+// Minimal fields we need: bucket, name, generation, metadata.
+type gcsFinalizeEvent struct {
+	Bucket     string            `json:"bucket"`
+	Name       string            `json:"name"`
+	Generation string            `json:"generation"`
+	Metadata   map[string]string `json:"metadata"`
+}
+
+// cloudEventEnvelope handles Eventarc structured content mode where the GCS
+// payload is nested inside a "data" field.
+type cloudEventEnvelope struct {
+	Data gcsFinalizeEvent `json:"data"`
+}
+
+// eventLease is how long a delivery owns an event. It covers the handler's 60s
+// timeout so a crashed delivery's event can be picked up by Eventarc's retry.
+const eventLease = 2 * time.Minute
+
+// ObjectStore is the object storage holding uploads. Missing objects are reported
+// with storage.ErrObjectNotExist.
+type ObjectStore interface {
+	Attrs(ctx context.Context, bucket, name string) (*storage.ObjectAttrs, error)
+	Delete(ctx context.Context, bucket, name string) error
+	// SetMetadata merges md into the object's metadata.
+	SetMetadata(ctx context.Context, bucket, name string, md map[string]string) error
+	// Promote moves from to to with the given download token and returns the token
+	// the promoted object ends up with.
+	Promote(ctx context.Context, bucket, from, to string, metadata map[string]string, token string) (string, error)
+}
+
+// ImageReferences updates the records pointing at a pending image once it is
+// decided (see services.PendingImageReferences).
+type ImageReferences interface {
+	Approve(ctx context.Context, uploadType, pendingPath, approvedURL string) error
+	Reject(ctx context.Context, uploadType, pendingPath string) error
+}
+
+// StrikeRecorder records a strike per moderation decision.
+type StrikeRecorder interface {
+	AddStrike(ctx context.Context, userID, reason, decisionID string) (*models.UserFlag, error)
+}
+
+// FinalizeHandler moderates images uploaded under pending/. Its dependencies are
+// built once at startup and shared by every event; at most maxConcurrent events are
+// handled at once.
+type FinalizeHandler struct {
+	objects    ObjectStore
+	classifier services.ImageClassifier
+	policies   *services.ModerationPolicies
+	refs       ImageReferences
+	strikes    StrikeRecorder
+	queue      services.ModerationQueueService
+	runs       services.ModerationRunService
+	events     services.ProcessedEventStore
+	sem        chan struct{}
+}
+
+// NewFinalizeHandler wires the handler. maxConcurrent below 1 means 1.
+func NewFinalizeHandler(objects ObjectStore, classifier services.ImageClassifier, policies *services.ModerationPolicies, refs ImageReferences, strikes StrikeRecorder, queue services.ModerationQueueService, runs services.ModerationRunService, events services.ProcessedEventStore, maxConcurrent int) *FinalizeHandler {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &FinalizeHandler{
+		objects:    objects,
+		classifier: classifier,
+		policies:   policies,
+		refs:       refs,
+		strikes:    strikes,
+		queue:      queue,
+		runs:       runs,
+		events:     events,
+		sem:        make(chan struct{}, maxConcurrent),
+	}
+}
+
+func (h *FinalizeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Only accept POSTs from Eventarc.
+	if r.Method != http.MethodPost {
+		log.Printf("[worker] rejected non-POST method=%s", r.Method)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Wait for a free slot; if the delivery gives up first, Eventarc retries it.
+	select {
+	case h.sem <- struct{}{}:
+		defer func() { <-h.sem }()
+	case <-r.Context().Done():
+		log.Printf("[worker] request cancelled while waiting for a slot")
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Log Eventarc/CloudEvent headers for diagnostics.
+	ceType := r.Header.Get("Ce-Type")
+	ceSource := r.Header.Get("Ce-Source")
+	ceSubject := r.Header.Get("Ce-Subject")
+	contentType := r.Header.Get("Content-Type")
+	log.Printf("[worker] event received: Ce-Type=%s Ce-Source=%s Ce-Subject=%s Content-Type=%s",
+		ceType, ceSource, ceSubject, contentType)
+
+	// Read raw body so we can log it and attempt multiple parse strategies.
+	rawBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("[worker] failed to read request body: %v", err)
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	log.Printf("[worker] raw event body (%d bytes): %s", len(rawBody), string(rawBody))
+
+	// Try to decode as a direct GCS notification (binary content mode).
+	var ev gcsFinalizeEvent
+	if err := json.Unmarshal(rawBody, &ev); err != nil {
+		log.Printf("[worker] failed to decode event body: %v", err)
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	// If bucket/name are empty, the event may be wrapped in a CloudEvent envelope
+	// (structured content mode) with the GCS data nested under "data".
+	if ev.Bucket == "" || ev.Name == "" {
+		log.Printf("[worker] top-level bucket/name empty, trying CloudEvent envelope parse")
+		var envelope cloudEventEnvelope
+		if err := json.Unmarshal(rawBody, &envelope); err == nil && envelope.Data.Bucket != "" && envelope.Data.Name != "" {
+			ev = envelope.Data
+			log.Printf("[worker] successfully parsed from CloudEvent envelope: bucket=%s name=%s", ev.Bucket, ev.Name)
+		} else {
+			log.Printf("[worker] CloudEvent envelope parse also failed or empty: bucket=%q name=%q err=%v",
+				envelope.Data.Bucket, envelope.Data.Name, err)
+		}
+	}
+
+	log.Printf("[worker] parsed event: bucket=%s name=%s metadata=%v", ev.Bucket, ev.Name, ev.Metadata)
+
+	// Only process pending uploads.
+	if ev.Bucket == "" || ev.Name == "" {
+		log.Printf("[worker] skipping event: bucket or name is empty after all parse attempts")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if !strings.HasPrefix(ev.Name, "pending/") {
+		log.Printf("[worker] skipping non-pending object: name=%s", ev.Name)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	h.handle(ctx, w, &ev)
+}
+
+// handle moderates one pending upload and writes the response.
+func (h *FinalizeHandler) handle(ctx context.Context, w http.ResponseWriter, ev *gcsFinalizeEvent) {
+	// If metadata or the generation was not in the event payload, fetch them directly
+	// from GCS.
+	if ev.Generation == "" || ev.Metadata == nil || (ev.Metadata["userId"] == "" && ev.Metadata["type"] == "") {
+		log.Printf("[worker] metadata or generation missing from event payload, fetching from GCS object attrs")
+		if attrs, err := h.objects.Attrs(ctx, ev.Bucket, ev.Name); err != nil {
+			log.Printf("[worker] failed to fetch GCS object attrs: %v", err)
+		} else {
+			if ev.Metadata == nil || (ev.Metadata["userId"] == "" && ev.Metadata["type"] == "") {
+				ev.Metadata = attrs.Metadata
+			}
+			if ev.Generation == "" {
+				ev.Generation = strconv.FormatInt(attrs.Generation, 10)
+			}
+			log.Printf("[worker] fetched GCS attrs: generation=%s metadata=%v", ev.Generation, ev.Metadata)
+		}
+	}
+
+	// Claim the event before touching anything so redeliveries and duplicates are
+	// handled once.
+	processed, err := h.events.Begin(ev.Bucket, ev.Name, ev.Generation, eventLease)
+	if err == services.ErrEventInProgress {
+		// Non-2xx makes Eventarc retry later, by which time the other delivery has
+		// finished (or its lease has run out).
+		log.Printf("[worker] event in progress elsewhere, deferring: name=%s generation=%s", ev.Name, ev.Generation)
+		http.Error(w, "event in progress", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Printf("[worker] claim event failed name=%s err=%v", ev.Name, err)
+		http.Error(w, "claim event failed", http.StatusInternalServerError)
+		return
+	}
+	if processed.Status == models.ProcessedEventDone {
+		log.Printf("[worker] replayed event, already processed: name=%s generation=%s outcome=%s", ev.Name, ev.Generation, processed.Outcome)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	key, lease := processed.Key, processed.LeaseID
+	log.Printf("[worker] event claimed: key=%s attempt=%d", key, processed.Attempts)
+
+	// fail gives the claim back so Eventarc's retry can start over at once; done marks
+	// the event handled. Both only touch the record while this delivery still holds
+	// the claim, so one that outlived its lease can't undo another delivery's work.
+	fail := func(msg string) {
+		if err := h.events.Release(key, lease); err != nil {
+			log.Printf("[worker] release event failed key=%s err=%v", key, err)
+		}
+		http.Error(w, msg, http.StatusInternalServerError)
+	}
+	done := func() {
+		if err := h.events.Complete(key, lease); err != nil {
+			log.Printf("[worker] complete event failed key=%s err=%v", key, err)
+			// The delivery that took the claim over decides the event's fate; a
+			// non-2xx keeps Eventarc retrying until that one has finished.
+			if err == services.ErrEventLeaseLost {
+				http.Error(w, "event lease lost", http.StatusConflict)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+
+	userID := ""
+	typ := ""
+	if ev.Metadata != nil {
+		userID = ev.Metadata["userId"]
+		typ = ev.Metadata["type"]
+	}
+	log.Printf("[worker] extracted metadata: userID=%s type=%s", userID, typ)
+
+	if userID == "" {
+		log.Printf("[worker] WARNING: userID is empty — Mongo lookups by pending path may still work but strikes cannot be recorded")
+	}
+	if typ == "" {
+		log.Printf("[worker] WARNING: type is empty — cannot determine which Mongo collection to update")
+	}
+
+	run := &models.ModerationRun{
+		ID:            uuid.New().String(),
+		TargetType:    models.ModerationTargetImage,
+		TargetID:      ev.Name,
+		UploadType:    typ,
+		UserID:        userID,
+		Source:        models.ModerationRunWorker,
+		PolicyVersion: h.policies.Version,
+		StartedAt:     time.Now().UTC(),
+	}
+
+	var decision services.ModerationDecision
+	if processed.Decided() {
+		// An earlier delivery classified the image and failed part way through
+		// carrying the decision out. Finish that decision under the same ID, so the
+		// strike and audit record aren't duplicated.
+		run.ID = processed.DecisionID
+		decision = services.ModerationDecision{Outcome: processed.Outcome, Rule: processed.Rule}
+		log.Printf("[worker] resuming saved decision for %s: decision=%s outcome=%s rule=%s", ev.Name, run.ID, decision.Outcome, decision.Rule)
+	} else {
+		log.Printf("[worker] classifying gs://%s/%s", ev.Bucket, ev.Name)
+		ss, err := h.classifier.Classify(ctx, services.ImageRef{Bucket: ev.Bucket, Name: ev.Name, Metadata: ev.Metadata})
+		if err != nil {
+			log.Printf("[worker] classify error bucket=%s name=%s err=%v", ev.Bucket, ev.Name, err)
+			run.Outcome = models.ModerationRunError
+			run.Error = err.Error()
+			h.recordRun(run)
+			// Retry by returning 500; Eventarc will retry.
+			fail("classify failed")
+			return
+		}
+
+		log.Printf("[worker] classifier result for %s: adult=%s violence=%s racy=%s spoof=%s medical=%s",
+			ev.Name, ss.Adult, ss.Violence, ss.Racy, ss.Spoof, ss.Medical)
+
+		decision = h.policies.Evaluate(typ, ss)
+		log.Printf("[worker] policy decision for %s: outcome=%s rule=%s", ev.Name, decision.Outcome, decision.Rule)
+
+		// Save the decision before acting on it; without it a retry would classify
+		// again under a new decision ID.
+		if err := h.events.SaveDecision(key, lease, run.ID, decision.Outcome, decision.Rule); err != nil {
+			log.Printf("[worker] save decision failed key=%s err=%v", key, err)
+			fail("save decision failed")
+			return
+		}
+
+		run.Scores = services.SafeSearchScores(ss)
+		run.Outcome = decision.Outcome
+		run.Rule = decision.Rule
+		h.recordRun(run)
+	}
+
+	// Review: leave the object under pending/ (references keep pointing at it),
+	// record why it was held and queue it for a human decision.
+	if decision.Outcome == services.ModerationReview {
+		if err := h.objects.SetMetadata(ctx, ev.Bucket, ev.Name, map[string]string{
+			"moderation":     services.ModerationReview,
+			"moderationRule": decision.Rule,
+		}); err != nil {
+			log.Printf("[worker] mark review failed bucket=%s name=%s err=%v", ev.Bucket, ev.Name, err)
+			fail("mark review failed")
+			return
+		}
+		if _, err := h.queue.Enqueue(&models.ModerationQueueItem{
+			TargetType: models.ModerationTargetImage,
+			TargetID:   ev.Name,
+			UploadType: typ,
+			UserID:     userID,
+			Source:     models.ModerationSourceClassifier,
+			Rule:       decision.Rule,
+		}); err != nil {
+			log.Printf("[worker] enqueue for review failed name=%s err=%v", ev.Name, err)
+			fail("enqueue failed")
+			return
+		}
+		log.Printf("[worker] DONE (review): name=%s rule=%s", ev.Name, decision.Rule)
+		done()
+		return
+	}
+
+	// Rejected: delete object and clear references + strike.
+	if decision.Outcome == services.ModerationReject {
+		log.Printf("[worker] image REJECTED (%s) — deleting object and clearing references: bucket=%s name=%s userID=%s type=%s",
+			decision.Rule, ev.Bucket, ev.Name, userID, typ)
+
+		if err := h.objects.Delete(ctx, ev.Bucket, ev.Name); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			log.Printf("[worker] delete object failed bucket=%s name=%s err=%v", ev.Bucket, ev.Name, err)
+			fail("delete failed")
+			return
+		}
+		log.Printf("[worker] deleted unsafe object from GCS: %s", ev.Name)
+
+		// Clear pending references + strike. Both are safe to repeat (strikes are keyed
+		// by decision ID), so a failure is retried from the saved decision.
+		if userID != "" {
+			if _, err := h.strikes.AddStrike(ctx, userID, decision.Rule, run.ID); err != nil {
+				log.Printf("[worker] failed to add strike for userID=%s: %v", userID, err)
+				fail("add strike failed")
+				return
+			}
+			log.Printf("[worker] strike recorded for userID=%s", userID)
+		}
+		if err := h.refs.Reject(ctx, typ, ev.Name); errors.Is(err, services.ErrNoImageReferences) {
+			log.Printf("[worker] WARNING: %v, no Mongo references cleared", err)
+		} else if err != nil {
+			log.Printf("[worker] clearing references failed type=%s path=%s: %v", typ, ev.Name, err)
+			fail("clear references failed")
+			return
+		} else {
+			log.Printf("[worker] rejected pending %s: path=%s", typ, ev.Name)
+		}
+
+		log.Printf("[worker] DONE (rejected): name=%s rule=%s", ev.Name, decision.Rule)
+		done()
+		return
+	}
+
+	// Safe: promote to approved path (strip pending/) and set moderation=approved.
+	finalName := strings.TrimPrefix(ev.Name, "pending/")
+
+	log.Printf("[worker] image SAFE — promoting: from=%s to=%s", ev.Name, finalName)
+
+	token, err := h.objects.Promote(ctx, ev.Bucket, ev.Name, finalName, ev.Metadata, newToken())
+	if err != nil {
+		log.Printf("[worker] promote failed bucket=%s from=%s to=%s err=%v", ev.Bucket, ev.Name, finalName, err)
+		fail("promote failed")
+		return
+	}
+	approvedURL := firebaseDownloadURL(ev.Bucket, finalName, token)
+	log.Printf("[worker] object promoted successfully in GCS: %s -> %s approvedURL=%s", ev.Name, finalName, approvedURL)
+
+	// Update Mongo to point to the approved download URL. Promote is idempotent, so a
+	// failure here is retried from the saved decision.
+	if err := h.refs.Approve(ctx, typ, ev.Name, approvedURL); errors.Is(err, services.ErrNoImageReferences) {
+		log.Printf("[worker] WARNING: %v, no Mongo references updated", err)
+	} else if err != nil {
+		log.Printf("[worker] updating references failed type=%s path=%s: %v", typ, ev.Name, err)
+		fail("update references failed")
+		return
+	} else {
+		log.Printf("[worker] approved %s: pendingPath=%s approvedURL=%s", typ, ev.Name, approvedURL)
+	}
+
+	log.Printf("[worker] DONE (safe): name=%s approvedURL=%s", ev.Name, approvedURL)
+	done()
+}
+
+// recordRun stores the run in the audit trail. Failures are logged rather than
+// retried so a history outage doesn't hold images in pending/.
+func (h *FinalizeHandler) recordRun(run *models.ModerationRun) {
+	run.CompletedAt = time.Now().UTC()
+	if err := h.runs.Record(run); err != nil {
+		log.Printf("[worker] record run failed name=%s err=%v", run.TargetID, err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/rummage/backend/internal/config"
 	"github.com/rummage/backend/internal/handlers"
 	appMiddleware "github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
 	"github.com/rummage/backend/internal/services"
 )
 
@@ -36,38 +37,217 @@ func main() {
 	if cfg.MongoURI == "" {
 		log.Fatalf("MONGO_URI is required")
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	salesService, err := services.NewMongoSalesService(ctx, cfg.MongoURI, cfg.MongoDB)
+	// Each service dials, pings and builds its indexes under its own deadline, so a
+	// slow cold start doesn't run the later ones out of time.
+	var cancels []context.CancelFunc
+	defer func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}()
+	initCtx := func() context.Context {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		cancels = append(cancels, cancel)
+		return ctx
+	}
+	salesService, err := services.NewMongoSalesService(initCtx(), cfg.MongoURI, cfg.MongoDB)
 	if err != nil {
 		// Common cause: Atlas Network Access doesn't allow Cloud Run egress.
 		log.Fatalf("Failed to initialize MongoDB sales service: %v", err)
 	}
-	favoriteService, err := services.NewMongoFavoriteService(ctx, cfg.MongoURI, cfg.MongoDB, salesService)
+	profileService, err := services.NewMongoProfileService(initCtx(), cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB profile service: %v", err)
+	}
+	reviewService, err := services.NewMongoReviewService(initCtx(), cfg.MongoURI, cfg.MongoDB, salesService)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB reviews service: %v", err)
+	}
+	// Push notifications. Devices register tokens; the dispatcher fans notifications out to them.
+	deviceService, err := services.NewMongoDeviceService(initCtx(), cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB devices service: %v", err)
+	}
+	var notifier services.Notifier
+	switch cfg.PushProvider {
+	case "fcm":
+		fcm, fcmErr := services.NewFCMNotifier(
+			context.Background(),
+			os.Getenv("FIREBASE_PROJECT_ID"),
+			os.Getenv("FIREBASE_CREDENTIALS_JSON"),
+		)
+		if fcmErr != nil {
+			log.Printf("Warning: failed to init FCM (push disabled): %v", fcmErr)
+		} else {
+			notifier = fcm
+		}
+	case "fake":
+		notifier = services.NewFakeNotifier()
+	}
+	notificationService, err := services.NewMongoNotificationService(initCtx(), cfg.MongoURI, cfg.MongoDB, cfg.NotificationTTL)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB notifications service: %v", err)
+	}
+	notificationPrefs, err := services.NewMongoNotificationPreferenceService(initCtx(), cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB notification preferences service: %v", err)
+	}
+
+	// Outgoing email for support, notification and account messages.
+	var mailer services.Mailer
+	mailProvider := cfg.MailProvider
+	if mailProvider == "" {
+		// Deploys configured before MAIL_PROVIDER existed only set SENDGRID_API_KEY.
+		mailProvider = "file"
+		if cfg.SendGridAPIKey != "" {
+			mailProvider = "sendgrid"
+		} else {
+			log.Printf("WARNING: MAIL_PROVIDER and SENDGRID_API_KEY are not set; email is written to %s and NOT sent. Set MAIL_PROVIDER=file to silence this in local dev", cfg.MailDir)
+		}
+	}
+	switch mailProvider {
+	case "sendgrid":
+		mailer = services.NewSendGridMailer(cfg.SendGridAPIKey, cfg.MailFrom, cfg.MailFromName)
+	case "smtp":
+		mailer = services.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom, cfg.MailFromName)
+	case "file":
+		mailer = services.NewFileMailer(cfg.MailDir, cfg.MailFrom, cfg.MailFromName)
+	default:
+		log.Fatalf("Unknown MAIL_PROVIDER %q", cfg.MailProvider)
+	}
+	log.Printf("Mail provider: %s", mailProvider)
+	// Everything is queued in the outbox first; the background sender retries failures.
+	emailOutbox, err := services.NewMongoEmailOutbox(initCtx(), cfg.MongoURI, cfg.MongoDB, mailer)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB email outbox: %v", err)
+	}
+	go emailOutbox.Run(context.Background(), 10*time.Second)
+	mailer = emailOutbox
+	emailResolver := services.NewUserEmailResolver(profileService, authClient)
+
+	notifications := services.NewNotificationDispatcher(
+		notificationService,
+		notificationService,
+		notificationPrefs,
+		deviceService,
+		notifier,
+		mailer,
+		emailResolver,
+	)
+	// Delivers pushes held for quiet hours and digests.
+	go notifications.RunHeldDeliveries(context.Background(), time.Minute)
+
+	// Sale events fan out to features that react to sale changes (follower notifications,
+	// saved-search alerts, favorite updates, ...).
+	saleEvents := services.NewSaleEventBus()
+
+	favoriteService, err := services.NewMongoFavoriteService(initCtx(), cfg.MongoURI, cfg.MongoDB, salesService, notifications)
 	if err != nil {
 		log.Fatalf("Failed to initialize MongoDB favorites service: %v", err)
 	}
-	profileService, err := services.NewMongoProfileService(ctx, cfg.MongoURI, cfg.MongoDB)
+	saleEvents.Subscribe(favoriteService)
+
+	followService, err := services.NewMongoFollowService(initCtx(), cfg.MongoURI, cfg.MongoDB, salesService, profileService, notifications)
 	if err != nil {
-		log.Fatalf("Failed to initialize MongoDB profile service: %v", err)
+		log.Fatalf("Failed to initialize MongoDB follows service: %v", err)
+	}
+	saleEvents.Subscribe(followService)
+
+	savedSearchService, err := services.NewMongoSavedSearchService(initCtx(), cfg.MongoURI, cfg.MongoDB, notifications)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB saved searches service: %v", err)
+	}
+	saleEvents.Subscribe(savedSearchService)
+
+	wantedService, err := services.NewMongoWantedService(initCtx(), cfg.MongoURI, cfg.MongoDB, notifications)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB wanted service: %v", err)
 	}
-	accountService, err := services.NewMongoAccountService(ctx, cfg.MongoURI, cfg.MongoDB)
+	saleEvents.Subscribe(wantedService)
+
+	eventService, err := services.NewMongoCommunityEventService(initCtx(), cfg.MongoURI, cfg.MongoDB, salesService, notifications)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB community events service: %v", err)
+	}
+	saleEvents.Subscribe(eventService)
+
+	curbAlertService, err := services.NewMongoCurbAlertService(initCtx(), cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB curb alerts service: %v", err)
+	}
+
+	accountService, err := services.NewMongoAccountService(initCtx(), cfg.MongoURI, cfg.MongoDB)
 	if err != nil {
 		log.Fatalf("Failed to initialize MongoDB account service: %v", err)
 	}
+	supportService, err := services.NewMongoSupportService(initCtx(), cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB support service: %v", err)
+	}
 	imageService := services.NewImageService(cfg.UploadDir)
-	recaptchaVerifier := services.NewRecaptchaVerifier(cfg.RecaptchaSecret)
-	sendGridMailer := services.NewSendGridMailer(cfg.SendGridAPIKey, cfg.SupportFromEmail, cfg.SupportToEmail)
+	var captchaVerifier services.CaptchaVerifier
+	switch cfg.CaptchaProvider {
+	case "recaptcha_v2", "":
+		captchaVerifier = services.NewRecaptchaVerifier(cfg.CaptchaSecret)
+	case "recaptcha_v3":
+		captchaVerifier = services.NewRecaptchaV3Verifier(cfg.CaptchaSecret, cfg.CaptchaMinScore, cfg.CaptchaAction)
+	case "hcaptcha":
+		captchaVerifier = services.NewHCaptchaVerifier(cfg.CaptchaSecret)
+	case "turnstile":
+		captchaVerifier = services.NewTurnstileVerifier(cfg.CaptchaSecret, cfg.CaptchaAction)
+	case "fake":
+		log.Printf("Warning: CAPTCHA_PROVIDER=fake accepts any token; do not use in production")
+		captchaVerifier = services.NewFakeCaptchaVerifier()
+	default:
+		log.Fatalf("Unknown CAPTCHA_PROVIDER %q", cfg.CaptchaProvider)
+	}
+
+	// Strikes and the human review queue are shared by image moderation and admin
+	// moderation decisions.
+	flagSvc, err := services.NewMongoUserFlagService(initCtx(), cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		log.Printf("Warning: failed to init user flag service (strikes disabled): %v", err)
+		flagSvc = nil
+	}
+	strikes := services.NewStrikeEnforcer(flagSvc, services.StrikePolicy{
+		UploadBlockAt: cfg.StrikeUploadBlockAt,
+		SaleBlockAt:   cfg.StrikeSaleBlockAt,
+		SuspendAt:     cfg.StrikeSuspendAt,
+		SuspendFor:    cfg.StrikeSuspendFor,
+		DecayAfter:    cfg.StrikeDecayAfter,
+	})
+	moderationQueue, err := services.NewMongoModerationQueue(initCtx(), cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB moderation queue: %v", err)
+	}
+	moderationRuns, err := services.NewMongoModerationRunService(initCtx(), cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB moderation runs service: %v", err)
+	}
+	moderationAppeals, err := services.NewMongoModerationAppealService(initCtx(), cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB moderation appeals service: %v", err)
+	}
+	reportService, err := services.NewMongoReportService(initCtx(), cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		log.Fatalf("Failed to initialize MongoDB report service: %v", err)
+	}
 
 	// Moderation service (nil-safe: if FIREBASE_BUCKET not set, moderation is skipped).
 	var moderationService *services.ModerationService
 	if cfg.FirebaseBucket != "" {
-		var flagSvc *services.MongoUserFlagService
-		flagSvc, err = services.NewMongoUserFlagService(ctx, cfg.MongoURI, cfg.MongoDB)
+		// A bad policy file is a deploy mistake; fail loudly rather than moderate loosely.
+		var policies *services.ModerationPolicies
+		policies, err = services.LoadModerationPolicies(cfg.ModerationPolicyFile)
 		if err != nil {
-			log.Printf("Warning: failed to init user flag service (strikes disabled): %v", err)
+			log.Fatalf("Invalid MODERATION_POLICY_FILE: %v", err)
+		}
+		var classifier services.ImageClassifier
+		classifier, err = services.NewImageClassifier(context.Background(), cfg.ModerationClassifier, cfg.ModerationClassifierURL)
+		if err == nil {
+			refs := services.NewPendingImageReferences(salesService, profileService, curbAlertService)
+			moderationService, err = services.NewModerationService(context.Background(), cfg.FirebaseBucket, classifier, policies, moderationQueue, moderationRuns, refs, strikes, notifications)
 		}
-		moderationService, err = services.NewModerationService(context.Background(), cfg.FirebaseBucket, flagSvc)
 		if err != nil {
 			log.Printf("Warning: failed to init moderation service (moderation disabled): %v", err)
 			moderationService = nil
@@ -75,14 +255,47 @@ func main() {
 			log.Printf("Moderation service enabled for bucket %s", cfg.FirebaseBucket)
 		}
 	}
+	moderationReviewer := services.NewModerationReviewer(moderationQueue, moderationRuns, moderationService, salesService, profileService, reviewService, strikes, notifications)
+	// Text moderation runs without a bucket; a bad policy or provider is a config error.
+	textPolicy, err := services.LoadTextPolicy(cfg.TextPolicyFile)
+	if err != nil {
+		log.Fatalf("Failed to load text moderation policy: %v", err)
+	}
+	textClassifier, err := services.NewTextClassifier(cfg.TextClassifier, cfg.TextClassifierURL, textPolicy)
+	if err != nil {
+		log.Fatalf("Failed to initialize text classifier: %v", err)
+	}
+	textModeration := services.NewTextModerationService(textClassifier, textPolicy, moderationRuns, moderationQueue, strikes, salesService, profileService, reviewService)
+	appealReviewer := services.NewAppealReviewer(moderationAppeals, moderationRuns, strikes, salesService, profileService, reviewService, mailer, emailResolver, cfg.SupportToEmail)
+	reportIntake := services.NewReportIntake(reportService, moderationQueue, salesService, profileService, notifications, cfg.ReportHideThreshold)
 
 	// Initialize handlers
-	salesHandler := handlers.NewSalesHandler(salesService, moderationService)
+	salesHandler := handlers.NewSalesHandler(salesService, moderationService, textModeration, saleEvents, notifications)
 	favoriteHandler := handlers.NewFavoriteHandler(favoriteService)
 	imageHandler := handlers.NewImageHandler(imageService, cfg.MaxUploadSizeMB)
-	profileHandler := handlers.NewProfileHandler(profileService, authClient, moderationService)
-	accountHandler := handlers.NewAccountHandler(accountService)
-	supportHandler := handlers.NewSupportHandler(recaptchaVerifier, sendGridMailer)
+	reviewHandler := handlers.NewReviewHandler(reviewService, textModeration)
+	followHandler := handlers.NewFollowHandler(followService)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchService)
+	wantedHandler := handlers.NewWantedHandler(wantedService)
+	curbAlertHandler := handlers.NewCurbAlertHandler(curbAlertService, moderationService)
+	eventHandler := handlers.NewCommunityEventHandler(eventService)
+	deviceHandler := handlers.NewDeviceHandler(deviceService)
+	outboxHandler := handlers.NewOutboxHandler(emailOutbox)
+	moderationHandler := handlers.NewModerationHandler(moderationQueue, moderationRuns, moderationReviewer)
+	appealHandler := handlers.NewModerationAppealHandler(moderationAppeals, appealReviewer)
+	reportHandler := handlers.NewReportHandler(reportService, reportIntake)
+	notificationHandler := handlers.NewNotificationHandler(notificationService, notificationPrefs)
+	profileHandler := handlers.NewProfileHandler(profileService, authClient, moderationService, textModeration, reviewService, followService)
+	accountHandler := handlers.NewAccountHandler(accountService, mailer, emailResolver)
+	supportHandler := handlers.NewSupportHandler(
+		captchaVerifier,
+		mailer,
+		supportService,
+		salesService,
+		emailResolver,
+		cfg.SupportToEmail,
+		cfg.SupportStatusURL,
+	)
 
 	// Create router
 	r := chi.NewRouter()
@@ -113,55 +326,178 @@ func main() {
 	r.Route("/api", func(r chi.Router) {
 		// Public routes (no Firebase auth). Intended for external website integrations.
 		r.Post("/support", supportHandler.SubmitSupportRequest)
+		r.Get("/support/{ticket}", supportHandler.GetTicketStatus)
 
 		// Protected routes
 		r.Group(func(r chi.Router) {
 			r.Use(appMiddleware.FirebaseAuth(authClient))
 
-			// Sales routes
-			r.Route("/sales", func(r chi.Router) {
-				r.Get("/", salesHandler.ListSales)
-				r.Get("/mine", salesHandler.ListMySales)
-				r.Get("/search", salesHandler.SearchSales)
-				r.Get("/bounds", salesHandler.ListSalesByBounds)
-				r.Post("/", salesHandler.CreateSale)
-
-				r.Route("/{saleId}", func(r chi.Router) {
-					r.Get("/", salesHandler.GetSale)
-					r.Put("/", salesHandler.UpdateSale)
-					r.Put("/cover", salesHandler.SetSaleCoverPhoto)
-					r.Delete("/", salesHandler.DeleteSale)
-					r.Post("/start", salesHandler.StartSale)
-					r.Post("/end", salesHandler.EndSale)
-
-					// Items
-					r.Post("/items", salesHandler.AddItem)
-					r.Put("/items/{itemId}", salesHandler.UpdateItem)
-					r.Delete("/items/{itemId}", salesHandler.DeleteItem)
-
-					// Favorites
-					r.Post("/favorite", favoriteHandler.AddFavorite)
-					r.Delete("/favorite", favoriteHandler.RemoveFavorite)
+			// Content routes. Suspended users can still read but not change anything;
+			// account, support, notification and moderation routes below stay open to
+			// them.
+			r.Group(func(r chi.Router) {
+				r.Use(appMiddleware.Restrict(strikes, models.RestrictionAccountSuspended))
+
+				// Sales routes
+				r.Route("/sales", func(r chi.Router) {
+					r.Get("/", salesHandler.ListSales)
+					r.Get("/mine", salesHandler.ListMySales)
+					r.Get("/cohosting", salesHandler.ListCoHostedSales)
+					r.Get("/search", salesHandler.SearchSales)
+					r.Get("/bounds", salesHandler.ListSalesByBounds)
+					r.With(appMiddleware.Restrict(strikes, models.RestrictionSalesBlocked)).Post("/", salesHandler.CreateSale)
+
+					r.Route("/{saleId}", func(r chi.Router) {
+						r.Get("/", salesHandler.GetSale)
+						r.Put("/", salesHandler.UpdateSale)
+						r.Put("/cover", salesHandler.SetSaleCoverPhoto)
+						r.Delete("/", salesHandler.DeleteSale)
+						r.Post("/start", salesHandler.StartSale)
+						r.Post("/end", salesHandler.EndSale)
+
+						// Items
+						r.Post("/items", salesHandler.AddItem)
+						r.Put("/items/{itemId}", salesHandler.UpdateItem)
+						r.Delete("/items/{itemId}", salesHandler.DeleteItem)
+
+						// Co-hosts
+						r.Post("/cohosts", salesHandler.InviteCoHost)
+						r.Post("/cohosts/accept", salesHandler.AcceptCoHostInvite)
+						r.Delete("/cohosts/{userId}", salesHandler.RemoveCoHost)
+
+						// Favorites
+						r.Post("/favorite", favoriteHandler.AddFavorite)
+						r.Delete("/favorite", favoriteHandler.RemoveFavorite)
+
+						// Reviews
+						r.Get("/reviews", reviewHandler.ListSaleReviews)
+						r.Post("/reviews", reviewHandler.CreateReview)
+						r.Post("/reviews/{reviewId}/reply", reviewHandler.ReplyToReview)
+					})
 				})
-			})
 
-			// Favorites list
-			r.Get("/favorites", favoriteHandler.ListFavorites)
-			r.Get("/favorites/sales", favoriteHandler.ListFavoriteSales)
+				// Favorites list
+				r.Get("/favorites", favoriteHandler.ListFavorites)
+				r.Get("/favorites/sales", favoriteHandler.ListFavoriteSales)
 
-			// Profile / account
-			r.Route("/profile", func(r chi.Router) {
-				r.Get("/", profileHandler.GetProfile)
-				r.Get("/{userId}", profileHandler.GetPublicProfileByUserID)
-				r.Put("/", profileHandler.UpsertProfile)
+				// Followed sellers
+				r.Get("/following", followHandler.ListFollowing)
+				r.Get("/following/sales", followHandler.ListFollowingSales)
+
+				// Saved searches
+				r.Route("/searches", func(r chi.Router) {
+					r.Get("/", savedSearchHandler.ListSavedSearches)
+					r.Post("/", savedSearchHandler.CreateSavedSearch)
+					r.Get("/alerts", savedSearchHandler.ListAlerts)
+					r.Delete("/{searchId}", savedSearchHandler.DeleteSavedSearch)
+				})
+
+				// Wanted posts
+				r.Route("/wanted", func(r chi.Router) {
+					r.Get("/", wantedHandler.ListWanted)
+					r.Post("/", wantedHandler.CreateWanted)
+					r.Get("/nearby", wantedHandler.ListNearby)
+					r.Get("/matches", wantedHandler.ListMatches)
+					r.Delete("/{wantedId}", wantedHandler.DeleteWanted)
+				})
+
+				// Curb alerts: free curbside items, shown on the map next to sales
+				r.Route("/curb", func(r chi.Router) {
+					r.Get("/", curbAlertHandler.ListCurbAlertsByBounds)
+					r.Get("/mine", curbAlertHandler.ListMyCurbAlerts)
+					r.Post("/", curbAlertHandler.CreateCurbAlert)
+					r.Get("/{curbId}", curbAlertHandler.GetCurbAlert)
+					r.Post("/{curbId}/gone", curbAlertHandler.MarkGone)
+					r.Delete("/{curbId}", curbAlertHandler.DeleteCurbAlert)
+				})
+
+				// Community events: neighborhood-wide sales grouping many sellers
+				r.Route("/events", func(r chi.Router) {
+					r.Get("/", eventHandler.ListEventsByBounds)
+					r.Get("/clusters", eventHandler.ListClusters)
+					r.Post("/", eventHandler.CreateEvent)
+
+					r.Route("/{eventId}", func(r chi.Router) {
+						r.Get("/", eventHandler.GetEvent)
+						r.Put("/", eventHandler.UpdateEvent)
+						r.Delete("/", eventHandler.DeleteEvent)
+						r.Post("/join", eventHandler.RequestJoin)
+						r.Get("/requests", eventHandler.ListRequests)
+						r.Post("/requests/{saleId}/approve", eventHandler.ApproveRequest)
+						r.Post("/requests/{saleId}/reject", eventHandler.RejectRequest)
+						r.Delete("/sales/{saleId}", eventHandler.RemoveSale)
+					})
+				})
+
+				// Profile / account
+				r.Route("/profile", func(r chi.Router) {
+					r.Get("/", profileHandler.GetProfile)
+					r.Get("/{userId}", profileHandler.GetPublicProfileByUserID)
+					r.Get("/{userId}/reviews", reviewHandler.ListSellerReviews)
+					r.Post("/{userId}/follow", followHandler.Follow)
+					r.Delete("/{userId}/follow", followHandler.Unfollow)
+					r.Put("/", profileHandler.UpsertProfile)
+				})
+
+				// Reports of sales, items and profiles
+				r.Post("/reports", reportHandler.CreateReport)
+
+				// Image upload
+				r.With(appMiddleware.Restrict(strikes, models.RestrictionUploadsBlocked)).Post("/upload", imageHandler.Upload)
+				r.Delete("/upload/{imageId}", imageHandler.Delete)
 			})
+
 			r.Route("/account", func(r chi.Router) {
 				r.Delete("/", accountHandler.DeleteAccount)
 			})
 
-			// Image upload
-			r.Post("/upload", imageHandler.Upload)
-			r.Delete("/upload/{imageId}", imageHandler.Delete)
+			// In-app support (identity comes from the token, so no CAPTCHA)
+			r.Post("/support/me", supportHandler.SubmitInAppSupportRequest)
+
+			// Push notification devices
+			r.Post("/devices", deviceHandler.RegisterDevice)
+			r.Delete("/devices/{token}", deviceHandler.UnregisterDevice)
+
+			// In-app notification inbox
+			r.Route("/notifications", func(r chi.Router) {
+				r.Get("/", notificationHandler.ListNotifications)
+				r.Get("/unread-count", notificationHandler.UnreadCount)
+				r.Get("/preferences", notificationHandler.GetPreferences)
+				r.Put("/preferences", notificationHandler.UpdatePreferences)
+				r.Post("/read-all", notificationHandler.MarkAllRead)
+				r.Post("/{notificationId}/read", notificationHandler.MarkRead)
+			})
+
+			// Moderation decisions about the caller's own content, and appeals against them
+			r.Get("/moderation/me", moderationHandler.ListMyDecisions)
+			r.Get("/moderation/appeals", appealHandler.ListMyAppeals)
+			r.Post("/moderation/appeals", appealHandler.CreateAppeal)
+
+			// Admin
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(appMiddleware.RequireAdmin(cfg.AdminUIDs))
+
+				r.Get("/outbox", outboxHandler.ListEmails)
+				r.Post("/outbox/{emailId}/retry", outboxHandler.RetryEmail)
+
+				r.Get("/support", supportHandler.ListTickets)
+				r.Get("/support/{ticket}", supportHandler.GetTicket)
+				r.Post("/support/{ticket}/reply", supportHandler.ReplyToTicket)
+				r.Put("/support/{ticket}/status", supportHandler.UpdateTicketStatus)
+
+				r.Get("/moderation", moderationHandler.ListQueue)
+				r.Get("/moderation/runs", moderationHandler.ListRuns)
+				r.Get("/moderation/runs/{runId}", moderationHandler.GetRun)
+				r.Get("/moderation/appeals", appealHandler.ListAppeals)
+				r.Get("/moderation/appeals/{appealId}", appealHandler.GetAppeal)
+				r.Post("/moderation/appeals/{appealId}/uphold", appealHandler.UpholdAppeal)
+				r.Post("/moderation/appeals/{appealId}/overturn", appealHandler.OverturnAppeal)
+				r.Get("/moderation/{itemId}", moderationHandler.GetQueueItem)
+				r.Post("/moderation/{itemId}/claim", moderationHandler.ClaimQueueItem)
+				r.Post("/moderation/{itemId}/approve", moderationHandler.ApproveQueueItem)
+				r.Post("/moderation/{itemId}/reject", moderationHandler.RejectQueueItem)
+				r.Get("/reports", reportHandler.ListReports)
+			})
 		})
 	})
 
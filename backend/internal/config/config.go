@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,14 +17,68 @@ type Config struct {
 	MongoDB         string
 	MaxUploadSizeMB int64
 
+	// Firebase UIDs allowed to use admin endpoints, in addition to users whose ID token
+	// carries the "admin" custom claim.
+	AdminUIDs []string
+
 	// Firebase Storage bucket for moderation (e.g. "rummage-31244.firebasestorage.app").
 	FirebaseBucket string
+	// Image classifier for moderation: "vision" (default), "fake" (decides by file name;
+	// local dev and tests) or "http" (a local model server at ModerationClassifierURL).
+	ModerationClassifier    string
+	ModerationClassifierURL string
+	// JSON file with per-category moderation thresholds; empty uses the built-in defaults.
+	ModerationPolicyFile string
+	// Text moderation for titles, descriptions and bios: "rules" (default; built-in
+	// word lists and patterns) or "http" (a model server at TextClassifierURL).
+	// TextPolicyFile is optional JSON overriding outcomes and rules per category.
+	TextClassifier    string
+	TextClassifierURL string
+	TextPolicyFile    string
+	// Strike enforcement: active strikes that block photo uploads, block creating
+	// sales, and (each one from StrikeSuspendAt on) suspend the account for
+	// StrikeSuspendFor. Strikes stop counting after StrikeDecayAfter.
+	StrikeUploadBlockAt int
+	StrikeSaleBlockAt   int
+	StrikeSuspendAt     int
+	StrikeSuspendFor    time.Duration
+	StrikeDecayAfter    time.Duration
+	// Number of different users whose reports hide a sale, item or profile pending
+	// review; 0 turns auto-hiding off and only stores the reports.
+	ReportHideThreshold int
+
+	// Push delivery: "fcm", "fake" (record sends, local dev) or empty to only log.
+	PushProvider string
+
+	// How long in-app inbox notifications are kept before the TTL index removes them.
+	NotificationTTL time.Duration
+
+	// Outgoing email: "sendgrid", "smtp" or "file" (write .eml files to MailDir). Empty
+	// picks sendgrid when an API key is set, otherwise file.
+	MailProvider   string
+	MailFrom       string
+	MailFromName   string
+	MailDir        string
+	SendGridAPIKey string
+	SMTPHost       string
+	SMTPPort       string
+	SMTPUsername   string
+	SMTPPassword   string
 
 	// Support form (public endpoint)
-	SendGridAPIKey   string
-	SupportToEmail   string
-	SupportFromEmail string
-	RecaptchaSecret  string
+	SupportToEmail string
+	// Optional web page for ticket lookups; confirmation emails link to it with
+	// ?ticket=...&secret=... appended.
+	SupportStatusURL string
+
+	// CAPTCHA for public forms: "recaptcha_v2" (default), "recaptcha_v3", "hcaptcha",
+	// "turnstile" or "fake" (accepts any token but "fail"; local dev only).
+	CaptchaProvider string
+	CaptchaSecret   string
+	// reCAPTCHA v3 only: minimum score to accept (0.0-1.0).
+	CaptchaMinScore float64
+	// reCAPTCHA v3 and Turnstile: expected action name; empty skips the check.
+	CaptchaAction string
 }
 
 func Load() *Config {
@@ -40,12 +96,45 @@ func Load() *Config {
 		MongoDB:         getEnv("MONGO_DB", "rummage"),
 		MaxUploadSizeMB: 10,
 
-		FirebaseBucket: getEnv("FIREBASE_BUCKET", ""),
+		AdminUIDs: getEnvList("ADMIN_UIDS"),
+
+		FirebaseBucket:          getEnv("FIREBASE_BUCKET", ""),
+		ModerationClassifier:    getEnv("MODERATION_CLASSIFIER", "vision"),
+		ModerationClassifierURL: getEnv("MODERATION_CLASSIFIER_URL", ""),
+		ModerationPolicyFile:    getEnv("MODERATION_POLICY_FILE", ""),
+		TextClassifier:          getEnv("TEXT_CLASSIFIER", "rules"),
+		TextClassifierURL:       getEnv("TEXT_CLASSIFIER_URL", ""),
+		TextPolicyFile:          getEnv("TEXT_POLICY_FILE", ""),
+
+		StrikeUploadBlockAt: getEnvInt("STRIKE_UPLOAD_BLOCK_AT", 3),
+		StrikeSaleBlockAt:   getEnvInt("STRIKE_SALE_BLOCK_AT", 5),
+		StrikeSuspendAt:     getEnvInt("STRIKE_SUSPEND_AT", 7),
+		StrikeSuspendFor:    time.Duration(getEnvInt("STRIKE_SUSPEND_DAYS", 7)) * 24 * time.Hour,
+		StrikeDecayAfter:    time.Duration(getEnvInt("STRIKE_DECAY_DAYS", 90)) * 24 * time.Hour,
+		ReportHideThreshold: getEnvInt("REPORT_HIDE_THRESHOLD", 3),
+
+		PushProvider:    getEnv("PUSH_PROVIDER", ""),
+		NotificationTTL: time.Duration(getEnvInt("NOTIFICATION_TTL_DAYS", 30)) * 24 * time.Hour,
+
+		MailProvider: getEnv("MAIL_PROVIDER", ""),
+		// SUPPORT_FROM_EMAIL predates the shared mailer; keep honouring it.
+		MailFrom:       getEnv("MAIL_FROM", getEnv("SUPPORT_FROM_EMAIL", "")),
+		MailFromName:   getEnv("MAIL_FROM_NAME", "Rummage"),
+		MailDir:        getEnv("MAIL_DIR", "./data/mail"),
+		SendGridAPIKey: getEnv("SENDGRID_API_KEY", ""),
+		SMTPHost:       getEnv("SMTP_HOST", ""),
+		SMTPPort:       getEnv("SMTP_PORT", "587"),
+		SMTPUsername:   getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:   getEnv("SMTP_PASSWORD", ""),
 
-		SendGridAPIKey:   getEnv("SENDGRID_API_KEY", ""),
 		SupportToEmail:   getEnv("SUPPORT_TO_EMAIL", "support@ludicrousapps.io"),
-		SupportFromEmail: getEnv("SUPPORT_FROM_EMAIL", ""),
-		RecaptchaSecret:  getEnv("RECAPTCHA_SECRET", ""),
+		SupportStatusURL: getEnv("SUPPORT_STATUS_URL", ""),
+
+		CaptchaProvider: getEnv("CAPTCHA_PROVIDER", "recaptcha_v2"),
+		// RECAPTCHA_SECRET predates provider selection; keep honouring it.
+		CaptchaSecret:   getEnv("CAPTCHA_SECRET", getEnv("RECAPTCHA_SECRET", "")),
+		CaptchaMinScore: getEnvFloat("CAPTCHA_MIN_SCORE", 0.5),
+		CaptchaAction:   getEnv("CAPTCHA_ACTION", ""),
 	}
 }
 
@@ -55,3 +144,32 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvList splits a comma-separated variable, dropping empty entries.
+func getEnvList(key string) []string {
+	out := make([]string, 0)
+	for _, part := range strings.Split(os.Getenv(key), ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value, exists := os.LookupEnv(key); exists {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"log"
 	"net/http"
 
 	"github.com/rummage/backend/internal/middleware"
@@ -10,11 +11,14 @@ import (
 )
 
 type AccountHandler struct {
-	accounts *services.MongoAccountService
+	accounts  *services.MongoAccountService
+	mailer    services.Mailer
+	addresses services.EmailAddressResolver
 }
 
-func NewAccountHandler(accounts *services.MongoAccountService) *AccountHandler {
-	return &AccountHandler{accounts: accounts}
+// NewAccountHandler takes an optional mailer and resolver used to confirm deletions by email.
+func NewAccountHandler(accounts *services.MongoAccountService, mailer services.Mailer, addresses services.EmailAddressResolver) *AccountHandler {
+	return &AccountHandler{accounts: accounts, mailer: mailer, addresses: addresses}
 }
 
 // DeleteAccount deletes all backend data for the authenticated user and returns image URLs to delete
@@ -29,12 +33,36 @@ func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), services.DefaultAccountTimeout())
 	defer cancel()
 
+	// Resolve the address before the profile it may come from is deleted.
+	var email, name string
+	if h.mailer != nil && h.addresses != nil {
+		var err error
+		if email, name, err = h.addresses.EmailForUser(ctx, userID); err != nil {
+			log.Printf("[DeleteAccount] user=%s resolve email error=%v", userID, err)
+		}
+	}
+
 	result, err := h.accounts.DeleteAccount(ctx, userID)
 	if err != nil {
 		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to delete account"))
 		return
 	}
 
+	if email != "" {
+		h.sendDeletedEmail(ctx, userID, email, name)
+	}
+
 	writeJSON(w, http.StatusOK, models.NewSuccessResponse(result))
 }
 
+func (h *AccountHandler) sendDeletedEmail(ctx context.Context, userID, email, name string) {
+	msg, err := services.RenderEmail("account_deleted", services.AccountDeletedEmail{Name: name})
+	if err == nil {
+		msg.To = email
+		msg.ToName = name
+		err = h.mailer.Send(ctx, msg)
+	}
+	if err != nil {
+		log.Printf("[DeleteAccount] user=%s confirmation email error=%v", userID, err)
+	}
+}
@@ -3,8 +3,13 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"log"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
 )
 
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -25,3 +30,52 @@ func isAtLeastAge(dob time.Time, years int) bool {
 	// If born on/after cutoff date, they are younger than required age.
 	return d.Before(cutoff) || d.Equal(cutoff)
 }
+
+// parseLimit reads a positive "limit" query parameter, falling back to def.
+func parseLimit(r *http.Request, def int) int {
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		return v
+	}
+	return def
+}
+
+// writeRestricted answers strike enforcement errors with 403 and their restriction
+// code, and reports whether err was one.
+func writeRestricted(w http.ResponseWriter, err error) bool {
+	var code string
+	switch err {
+	case services.ErrUploadsBlocked:
+		code = models.RestrictionUploadsBlocked
+	case services.ErrSalesBlocked:
+		code = models.RestrictionSalesBlocked
+	case services.ErrAccountSuspended:
+		code = models.RestrictionAccountSuspended
+	default:
+		return false
+	}
+	writeJSON(w, http.StatusForbidden, models.NewCodedErrorResponse(code, models.RestrictionMessage(code, nil)))
+	return true
+}
+
+// checkText runs text moderation over fields, masking them in place. Rejected text
+// is recorded with a strike and answered with 422, but only once authorize confirms
+// the user may make the change; authorize writes the 403 or 404 itself when they
+// may not, and is nil for content the user is creating for themselves. Classifier
+// failures are logged and let through. It reports whether the handler should go
+// on, and the decision to Apply once the content is saved.
+func checkText(w http.ResponseWriter, r *http.Request, text *services.TextModerationService, userID, targetType, targetID string, fields map[string]*string, authorize func() bool) (*services.TextDecision, bool) {
+	decision, err := text.Check(r.Context(), fields)
+	if err != nil {
+		log.Printf("[TextModeration] user=%s target=%s/%s error=%v", userID, targetType, targetID, err)
+		return nil, true
+	}
+	if decision.Outcome == services.ModerationReject {
+		if authorize != nil && !authorize() {
+			return nil, false
+		}
+		text.Apply(r.Context(), decision, userID, targetType, targetID)
+		writeJSON(w, http.StatusUnprocessableEntity, models.NewValidationErrorResponse(decision.Rejected))
+		return nil, false
+	}
+	return decision, true
+}
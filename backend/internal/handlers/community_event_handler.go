@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+type CommunityEventHandler struct {
+	eventService services.CommunityEventService
+}
+
+func NewCommunityEventHandler(eventService services.CommunityEventService) *CommunityEventHandler {
+	return &CommunityEventHandler{
+		eventService: eventService,
+	}
+}
+
+func (h *CommunityEventHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.CreateCommunityEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	event, err := h.eventService.CreateEvent(userID, &req)
+	if err != nil {
+		log.Printf("[CreateEvent] user=%s error=%v", userID, err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to create event"))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(event))
+}
+
+// GetEvent serves the event page: the event and its approved member sales.
+func (h *CommunityEventHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "eventId")
+
+	event, err := h.eventService.GetEvent(eventID)
+	if err != nil {
+		writeEventError(w, err, "Failed to get event")
+		return
+	}
+
+	sales, err := h.eventService.ListMemberSales(eventID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list event sales"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(&models.CommunityEventPage{Event: event, Sales: sales}))
+}
+
+func (h *CommunityEventHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	eventID := chi.URLParam(r, "eventId")
+
+	var req models.CreateCommunityEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	event, err := h.eventService.UpdateEvent(userID, eventID, &req)
+	if err != nil {
+		writeEventError(w, err, "Failed to update event")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(event))
+}
+
+func (h *CommunityEventHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	eventID := chi.URLParam(r, "eventId")
+
+	if err := h.eventService.DeleteEvent(userID, eventID); err != nil {
+		writeEventError(w, err, "Failed to delete event")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]string{"message": "Event deleted successfully"}))
+}
+
+func (h *CommunityEventHandler) ListEventsByBounds(w http.ResponseWriter, r *http.Request) {
+	minLat, maxLat, minLng, maxLng, ok := parseBounds(w, r)
+	if !ok {
+		return
+	}
+
+	events, err := h.eventService.ListByBounds(minLat, maxLat, minLng, maxLng, 200)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list events"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(events))
+}
+
+// ListClusters returns one map cluster per event in view with its member sale IDs, so
+// the client can fold those sale pins into the event.
+func (h *CommunityEventHandler) ListClusters(w http.ResponseWriter, r *http.Request) {
+	minLat, maxLat, minLng, maxLng, ok := parseBounds(w, r)
+	if !ok {
+		return
+	}
+
+	clusters, err := h.eventService.ListClusters(minLat, maxLat, minLng, maxLng, 200)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list event clusters"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(clusters))
+}
+
+func (h *CommunityEventHandler) RequestJoin(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	eventID := chi.URLParam(r, "eventId")
+
+	var req models.JoinCommunityEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	membership, err := h.eventService.RequestJoin(userID, eventID, req.SaleID)
+	if err != nil {
+		writeEventError(w, err, "Failed to request to join event")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(membership))
+}
+
+func (h *CommunityEventHandler) ListRequests(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	eventID := chi.URLParam(r, "eventId")
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", models.EventMembershipPending, models.EventMembershipApproved, models.EventMembershipRejected:
+	default:
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid status"))
+		return
+	}
+
+	requests, err := h.eventService.ListRequests(userID, eventID, status)
+	if err != nil {
+		writeEventError(w, err, "Failed to list join requests")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(requests))
+}
+
+func (h *CommunityEventHandler) ApproveRequest(w http.ResponseWriter, r *http.Request) {
+	h.decideRequest(w, r, true)
+}
+
+func (h *CommunityEventHandler) RejectRequest(w http.ResponseWriter, r *http.Request) {
+	h.decideRequest(w, r, false)
+}
+
+func (h *CommunityEventHandler) decideRequest(w http.ResponseWriter, r *http.Request, approve bool) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	eventID := chi.URLParam(r, "eventId")
+	saleID := chi.URLParam(r, "saleId")
+
+	membership, err := h.eventService.DecideRequest(userID, eventID, saleID, approve)
+	if err != nil {
+		writeEventError(w, err, "Failed to update join request")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(membership))
+}
+
+func (h *CommunityEventHandler) RemoveSale(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	eventID := chi.URLParam(r, "eventId")
+	saleID := chi.URLParam(r, "saleId")
+
+	if err := h.eventService.RemoveSale(userID, eventID, saleID); err != nil {
+		writeEventError(w, err, "Failed to remove sale from event")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]string{"message": "Sale removed from event"}))
+}
+
+func parseBounds(w http.ResponseWriter, r *http.Request) (minLat, maxLat, minLng, maxLng float64, ok bool) {
+	query := r.URL.Query()
+
+	minLat, err1 := strconv.ParseFloat(query.Get("minLat"), 64)
+	maxLat, err2 := strconv.ParseFloat(query.Get("maxLat"), 64)
+	minLng, err3 := strconv.ParseFloat(query.Get("minLng"), 64)
+	maxLng, err4 := strconv.ParseFloat(query.Get("maxLng"), 64)
+
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Missing or invalid bounding box parameters (minLat, maxLat, minLng, maxLng)"))
+		return 0, 0, 0, 0, false
+	}
+	return minLat, maxLat, minLng, maxLng, true
+}
+
+// writeEventError maps community event service errors to responses.
+func writeEventError(w http.ResponseWriter, err error, fallback string) {
+	switch err {
+	case services.ErrEventNotFound:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Event not found"))
+	case services.ErrSaleNotFound:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Sale not found"))
+	case services.ErrEventMembershipNone:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Sale is not part of this event"))
+	case services.ErrEventForbidden:
+		writeJSON(w, http.StatusForbidden, models.NewErrorResponse("Only the organizer can manage this event"))
+	case services.ErrUnauthorized:
+		writeJSON(w, http.StatusForbidden, models.NewErrorResponse("Not authorized to add this sale"))
+	case services.ErrEventMembershipFound:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("Sale already requested to join this event"))
+	case services.ErrEventSaleOutside:
+		writeJSON(w, http.StatusUnprocessableEntity, models.NewErrorResponse("Sale is outside the event boundary"))
+	case services.ErrEventSaleDates:
+		writeJSON(w, http.StatusUnprocessableEntity, models.NewErrorResponse("Sale dates do not overlap the event"))
+	default:
+		log.Printf("[events] error=%v", err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse(fallback))
+	}
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+type CurbAlertHandler struct {
+	curbAlertService  services.CurbAlertService
+	moderationService *services.ModerationService
+}
+
+func NewCurbAlertHandler(curbAlertService services.CurbAlertService, moderationService *services.ModerationService) *CurbAlertHandler {
+	return &CurbAlertHandler{
+		curbAlertService:  curbAlertService,
+		moderationService: moderationService,
+	}
+}
+
+func (h *CurbAlertHandler) CreateCurbAlert(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.CreateCurbAlertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	if h.moderationService != nil {
+		res, err := h.moderationService.ModerateAndPromote(r.Context(), req.PhotoURL, userID, services.UploadTypeCurbAlert)
+		if err != nil {
+			if writeRestricted(w, err) {
+				return
+			}
+			if err == services.ErrImageRejected {
+				writeJSON(w, http.StatusUnprocessableEntity, models.NewErrorResponse("Photo rejected — violates community guidelines"))
+				return
+			}
+			log.Printf("[CreateCurbAlert] moderation error: %v", err)
+			writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to process image"))
+			return
+		}
+		req.PhotoURL = res.URL()
+	}
+
+	alert, err := h.curbAlertService.CreateCurbAlert(userID, &req)
+	if err != nil {
+		if err == services.ErrCurbAlertLimit {
+			writeJSON(w, http.StatusConflict, models.NewErrorResponse("Too many active curb alerts"))
+			return
+		}
+		log.Printf("[CreateCurbAlert] user=%s error=%v", userID, err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to create curb alert"))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(alert))
+}
+
+// ListCurbAlertsByBounds returns live curb alerts for the map; it takes the same
+// bounding box parameters as the sales bounds query.
+func (h *CurbAlertHandler) ListCurbAlertsByBounds(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	minLat, err1 := strconv.ParseFloat(query.Get("minLat"), 64)
+	maxLat, err2 := strconv.ParseFloat(query.Get("maxLat"), 64)
+	minLng, err3 := strconv.ParseFloat(query.Get("minLng"), 64)
+	maxLng, err4 := strconv.ParseFloat(query.Get("maxLng"), 64)
+
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Missing or invalid bounding box parameters (minLat, maxLat, minLng, maxLng)"))
+		return
+	}
+
+	limit := 500
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		if v, err := strconv.Atoi(rawLimit); err == nil && v > 0 {
+			limit = v
+		}
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	alerts, err := h.curbAlertService.ListByBounds(minLat, maxLat, minLng, maxLng, limit)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list curb alerts"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(alerts))
+}
+
+func (h *CurbAlertHandler) ListMyCurbAlerts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	alerts, err := h.curbAlertService.ListMine(userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list curb alerts"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(alerts))
+}
+
+func (h *CurbAlertHandler) GetCurbAlert(w http.ResponseWriter, r *http.Request) {
+	alertID := chi.URLParam(r, "curbId")
+
+	alert, err := h.curbAlertService.GetCurbAlert(alertID)
+	if err != nil {
+		if err == services.ErrCurbAlertNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Curb alert not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to get curb alert"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(alert))
+}
+
+func (h *CurbAlertHandler) MarkGone(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	alertID := chi.URLParam(r, "curbId")
+
+	alert, err := h.curbAlertService.MarkGone(userID, alertID)
+	if err != nil {
+		if err == services.ErrCurbAlertNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Curb alert not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to update curb alert"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(alert))
+}
+
+func (h *CurbAlertHandler) DeleteCurbAlert(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	alertID := chi.URLParam(r, "curbId")
+
+	err := h.curbAlertService.DeleteCurbAlert(userID, alertID)
+	if err != nil {
+		if err == services.ErrCurbAlertNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Curb alert not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to delete curb alert"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]string{"message": "Curb alert deleted successfully"}))
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+type DeviceHandler struct {
+	deviceService services.DeviceService
+}
+
+func NewDeviceHandler(deviceService services.DeviceService) *DeviceHandler {
+	return &DeviceHandler{
+		deviceService: deviceService,
+	}
+}
+
+// RegisterDevice stores (or refreshes) a push token for the authenticated user.
+func (h *DeviceHandler) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.RegisterDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	device, err := h.deviceService.RegisterDevice(userID, &req)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to register device"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(device))
+}
+
+func (h *DeviceHandler) UnregisterDevice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	token := chi.URLParam(r, "token")
+
+	err := h.deviceService.UnregisterDevice(userID, token)
+	if err != nil {
+		if err == services.ErrDeviceNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Device not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to unregister device"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]string{"message": "Device unregistered successfully"}))
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+type FollowHandler struct {
+	followService services.FollowService
+}
+
+func NewFollowHandler(followService services.FollowService) *FollowHandler {
+	return &FollowHandler{
+		followService: followService,
+	}
+}
+
+func (h *FollowHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	sellerID := chi.URLParam(r, "userId")
+
+	follow, err := h.followService.Follow(userID, sellerID)
+	if err != nil {
+		if err == services.ErrAlreadyFollowing {
+			writeJSON(w, http.StatusConflict, models.NewErrorResponse("Already following this seller"))
+			return
+		}
+		if err == services.ErrFollowSelf {
+			writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("You cannot follow yourself"))
+			return
+		}
+		if err == services.ErrFollowSellerNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Seller not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to follow seller"))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(follow))
+}
+
+func (h *FollowHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	sellerID := chi.URLParam(r, "userId")
+
+	err := h.followService.Unfollow(userID, sellerID)
+	if err != nil {
+		if err == services.ErrFollowNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Not following this seller"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to unfollow seller"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]string{"message": "Unfollowed successfully"}))
+}
+
+func (h *FollowHandler) ListFollowing(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	follows, err := h.followService.ListFollowing(userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list followed sellers"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(follows))
+}
+
+func (h *FollowHandler) ListFollowingSales(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	sales, err := h.followService.ListFollowingSales(userID, 200)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list sales"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(sales))
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+// ModerationAppealHandler lets users appeal moderation decisions and admins rule on
+// the appeals.
+type ModerationAppealHandler struct {
+	appeals  services.ModerationAppealService
+	reviewer *services.AppealReviewer
+}
+
+func NewModerationAppealHandler(appeals services.ModerationAppealService, reviewer *services.AppealReviewer) *ModerationAppealHandler {
+	return &ModerationAppealHandler{
+		appeals:  appeals,
+		reviewer: reviewer,
+	}
+}
+
+// CreateAppeal appeals one of the caller's rejections by decision ID.
+func (h *ModerationAppealHandler) CreateAppeal(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.CreateModerationAppealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	appeal, err := h.reviewer.File(userID, &req)
+	if err != nil {
+		writeAppealError(w, err, "Failed to file appeal")
+		return
+	}
+	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(appeal))
+}
+
+func (h *ModerationAppealHandler) ListMyAppeals(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	appeals, err := h.appeals.ListByUser(userID, parseLimit(r, 50))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list appeals"))
+		return
+	}
+	for _, appeal := range appeals {
+		// Reviewers stay anonymous to the people they moderate.
+		appeal.ReviewerID = ""
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(appeals))
+}
+
+// ListAppeals lists appeals for admins (query: status = pending (default), upheld,
+// overturned or all; limit), oldest first.
+func (h *ModerationAppealHandler) ListAppeals(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch {
+	case status == "":
+		status = models.ModerationAppealPending
+	case status == "all":
+		status = ""
+	case !models.IsModerationAppealStatus(status):
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid status"))
+		return
+	}
+
+	appeals, err := h.appeals.List(status, parseLimit(r, 100))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list appeals"))
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(appeals))
+}
+
+func (h *ModerationAppealHandler) GetAppeal(w http.ResponseWriter, r *http.Request) {
+	appeal, err := h.appeals.Get(chi.URLParam(r, "appealId"))
+	if err != nil {
+		writeAppealError(w, err, "Failed to get appeal")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(appeal))
+}
+
+func (h *ModerationAppealHandler) UpholdAppeal(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, models.ModerationAppealUpheld)
+}
+
+func (h *ModerationAppealHandler) OverturnAppeal(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, models.ModerationAppealOverturned)
+}
+
+func (h *ModerationAppealHandler) resolve(w http.ResponseWriter, r *http.Request, status string) {
+	adminID := middleware.GetUserID(r.Context())
+	if adminID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.ModerationDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	appealID := chi.URLParam(r, "appealId")
+	appeal, err := h.reviewer.Resolve(r.Context(), appealID, adminID, status, req.Reason)
+	if err != nil {
+		log.Printf("[AppealResolve] appeal=%s status=%s error=%v", appealID, status, err)
+		writeAppealError(w, err, "Failed to resolve appeal")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(appeal))
+}
+
+func writeAppealError(w http.ResponseWriter, err error, fallback string) {
+	switch err {
+	case services.ErrAppealNotFound:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Appeal not found"))
+	case services.ErrModerationRunNotFound:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Moderation decision not found"))
+	case services.ErrDecisionNotAppealable:
+		writeJSON(w, http.StatusUnprocessableEntity, models.NewErrorResponse("Only rejections can be appealed"))
+	case services.ErrAppealExists:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("This decision has already been appealed"))
+	case services.ErrAppealResolved:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("This appeal has already been resolved"))
+	case services.ErrModerationBadInput:
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request"))
+	default:
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse(fallback))
+	}
+}
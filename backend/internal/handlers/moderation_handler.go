@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+// ModerationHandler serves the moderation queue and decision history to admins, and
+// users' own moderation decisions to them.
+type ModerationHandler struct {
+	queue    services.ModerationQueueService
+	runs     services.ModerationRunService
+	reviewer *services.ModerationReviewer
+}
+
+func NewModerationHandler(queue services.ModerationQueueService, runs services.ModerationRunService, reviewer *services.ModerationReviewer) *ModerationHandler {
+	return &ModerationHandler{
+		queue:    queue,
+		runs:     runs,
+		reviewer: reviewer,
+	}
+}
+
+// ListMyDecisions returns moderation decisions about the caller's content (query:
+// outcome = reject (default), review, approve or all; limit). The decision ID is
+// what support and appeals refer to.
+func (h *ModerationHandler) ListMyDecisions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	outcome := r.URL.Query().Get("outcome")
+	switch outcome {
+	case "":
+		outcome = services.ModerationReject
+	case "all":
+		outcome = ""
+	case services.ModerationReject, services.ModerationReview, services.ModerationApprove:
+	default:
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid outcome"))
+		return
+	}
+
+	runs, err := h.runs.ListByUser(userID, outcome, parseLimit(r, 50))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list moderation decisions"))
+		return
+	}
+	for _, run := range runs {
+		// Reviewers stay anonymous to the people they moderate.
+		run.ReviewerID = ""
+		run.QueueItemID = ""
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(runs))
+}
+
+// ListRuns queries the moderation history (query: user_id, target_id, upload_type,
+// source, outcome, rule, from and to as RFC 3339, limit), newest first.
+func (h *ModerationHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := models.ModerationRunQuery{
+		UserID:     query.Get("user_id"),
+		TargetID:   query.Get("target_id"),
+		UploadType: query.Get("upload_type"),
+		Source:     query.Get("source"),
+		Outcome:    query.Get("outcome"),
+		Rule:       query.Get("rule"),
+		Limit:      parseLimit(r, 100),
+	}
+	for param, dst := range map[string]*time.Time{"from": &q.From, "to": &q.To} {
+		raw := query.Get(param)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid "+param+" (want RFC 3339)"))
+			return
+		}
+		*dst = t
+	}
+
+	runs, err := h.runs.Query(q)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to query moderation history"))
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(runs))
+}
+
+func (h *ModerationHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	run, err := h.runs.Get(chi.URLParam(r, "runId"))
+	if err != nil {
+		if err == services.ErrModerationRunNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Moderation decision not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to get moderation decision"))
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(run))
+}
+
+// ListQueue lists queue entries (query: status, type, limit). Defaults to open
+// (pending or claimed) entries, oldest first.
+func (h *ModerationHandler) ListQueue(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	status := query.Get("status")
+	switch {
+	case status == "":
+		status = services.ModerationQueueOpen
+	case status == "all":
+		status = ""
+	case status == services.ModerationQueueOpen:
+	case !models.IsModerationQueueStatus(status):
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid status"))
+		return
+	}
+	targetType := query.Get("type")
+	if targetType != "" && !models.IsModerationTarget(targetType) {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid type"))
+		return
+	}
+
+	items, err := h.queue.List(status, targetType, parseLimit(r, 100))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list moderation queue"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(items))
+}
+
+func (h *ModerationHandler) GetQueueItem(w http.ResponseWriter, r *http.Request) {
+	item, err := h.queue.Get(chi.URLParam(r, "itemId"))
+	if err != nil {
+		writeModerationError(w, err, "Failed to get moderation item")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(item))
+}
+
+// ClaimQueueItem assigns the entry to the calling admin so others skip it.
+func (h *ModerationHandler) ClaimQueueItem(w http.ResponseWriter, r *http.Request) {
+	adminID := middleware.GetUserID(r.Context())
+	if adminID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	item, err := h.queue.Claim(chi.URLParam(r, "itemId"), adminID)
+	if err != nil {
+		writeModerationError(w, err, "Failed to claim moderation item")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(item))
+}
+
+func (h *ModerationHandler) ApproveQueueItem(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, models.ModerationQueueApproved)
+}
+
+func (h *ModerationHandler) RejectQueueItem(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, models.ModerationQueueRejected)
+}
+
+func (h *ModerationHandler) decide(w http.ResponseWriter, r *http.Request, status string) {
+	adminID := middleware.GetUserID(r.Context())
+	if adminID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.ModerationDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	itemID := chi.URLParam(r, "itemId")
+	item, err := h.reviewer.Decide(r.Context(), itemID, adminID, status, req.Reason)
+	if err != nil {
+		log.Printf("[ModerationDecide] item=%s status=%s error=%v", itemID, status, err)
+		writeModerationError(w, err, "Failed to apply moderation decision")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(item))
+}
+
+func writeModerationError(w http.ResponseWriter, err error, fallback string) {
+	switch err {
+	case services.ErrModerationItemNotFound:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Moderation item not found"))
+	case services.ErrModerationItemClaimed:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("Another reviewer has claimed this item"))
+	case services.ErrModerationItemDecided:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("This item has already been decided"))
+	case services.ErrModerationUnavailable:
+		writeJSON(w, http.StatusServiceUnavailable, models.NewErrorResponse("Image moderation is not configured"))
+	case services.ErrModerationBadInput:
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request"))
+	default:
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse(fallback))
+	}
+}
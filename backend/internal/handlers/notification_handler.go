@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+type NotificationHandler struct {
+	inbox services.NotificationInbox
+	prefs services.NotificationPreferenceService
+}
+
+func NewNotificationHandler(inbox services.NotificationInbox, prefs services.NotificationPreferenceService) *NotificationHandler {
+	return &NotificationHandler{
+		inbox: inbox,
+		prefs: prefs,
+	}
+}
+
+// ListNotifications returns a page of the user's inbox (query: limit, before).
+func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	query := r.URL.Query()
+
+	limit := 50
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		if v, err := strconv.Atoi(rawLimit); err == nil && v > 0 {
+			limit = v
+		}
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	var before time.Time
+	if rawBefore := query.Get("before"); rawBefore != "" {
+		t, err := time.Parse(time.RFC3339Nano, rawBefore)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid before cursor"))
+			return
+		}
+		before = t
+	}
+
+	page, err := h.inbox.List(userID, before, limit)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list notifications"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(page))
+}
+
+func (h *NotificationHandler) UnreadCount(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	count, err := h.inbox.UnreadCount(userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to count notifications"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]int{"unread_count": count}))
+}
+
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	notificationID := chi.URLParam(r, "notificationId")
+
+	n, err := h.inbox.MarkRead(userID, notificationID)
+	if err != nil {
+		if err == services.ErrNotificationNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Notification not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to mark notification read"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(n))
+}
+
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	updated, err := h.inbox.MarkAllRead(userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to mark notifications read"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]int{"updated": updated}))
+}
+
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	prefs, err := h.prefs.GetPreferences(r.Context(), userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to get notification preferences"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(prefs))
+}
+
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.UpdateNotificationPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	prefs, err := h.prefs.UpdatePreferences(userID, &req)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to update notification preferences"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(prefs))
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+// OutboxHandler gives admins visibility into queued and failed outgoing email.
+type OutboxHandler struct {
+	outbox services.EmailOutboxService
+}
+
+func NewOutboxHandler(outbox services.EmailOutboxService) *OutboxHandler {
+	return &OutboxHandler{
+		outbox: outbox,
+	}
+}
+
+// ListEmails lists outbox messages (query: status, limit). Defaults to dead letters.
+func (h *OutboxHandler) ListEmails(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	status := query.Get("status")
+	switch status {
+	case "":
+		status = models.OutboxEmailDead
+	case "all":
+		status = ""
+	case models.OutboxEmailPending, models.OutboxEmailSending, models.OutboxEmailSent, models.OutboxEmailDead:
+	default:
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid status"))
+		return
+	}
+
+	limit := 100
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		if v, err := strconv.Atoi(rawLimit); err == nil && v > 0 {
+			limit = v
+		}
+	}
+
+	emails, err := h.outbox.ListEmails(status, limit)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list outbox"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(emails))
+}
+
+func (h *OutboxHandler) RetryEmail(w http.ResponseWriter, r *http.Request) {
+	emailID := chi.URLParam(r, "emailId")
+
+	email, err := h.outbox.RetryEmail(emailID)
+	if err != nil {
+		if err == services.ErrOutboxEmailNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Email not found or already sent"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to retry email"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(email))
+}
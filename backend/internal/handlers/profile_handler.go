@@ -20,10 +20,13 @@ type ProfileHandler struct {
 	profiles          *services.MongoProfileService
 	authClient        *fbauth.Client
 	moderationService *services.ModerationService
+	textModeration    *services.TextModerationService
+	reviews           services.ReviewService
+	follows           services.FollowService
 }
 
-func NewProfileHandler(profiles *services.MongoProfileService, authClient *fbauth.Client, moderationService *services.ModerationService) *ProfileHandler {
-	return &ProfileHandler{profiles: profiles, authClient: authClient, moderationService: moderationService}
+func NewProfileHandler(profiles *services.MongoProfileService, authClient *fbauth.Client, moderationService *services.ModerationService, textModeration *services.TextModerationService, reviews services.ReviewService, follows services.FollowService) *ProfileHandler {
+	return &ProfileHandler{profiles: profiles, authClient: authClient, moderationService: moderationService, textModeration: textModeration, reviews: reviews, follows: follows}
 }
 
 func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
@@ -76,9 +79,20 @@ func (h *ProfileHandler) UpsertProfile(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	text, ok := checkText(w, r, h.textModeration, userID, models.ModerationTargetProfile, userID, map[string]*string{
+		"display_name": req.DisplayName,
+		"bio":          req.Bio,
+	}, nil)
+	if !ok {
+		return
+	}
+
 	if h.moderationService != nil && req.PhotoURL != nil && strings.HasPrefix(*req.PhotoURL, "pending/") {
-		res, mErr := h.moderationService.ModerateAndPromote(r.Context(), *req.PhotoURL, userID)
+		res, mErr := h.moderationService.ModerateAndPromote(r.Context(), *req.PhotoURL, userID, services.UploadTypeProfilePhoto)
 		if mErr != nil {
+			if writeRestricted(w, mErr) {
+				return
+			}
 			if mErr == services.ErrImageRejected {
 				writeJSON(w, http.StatusUnprocessableEntity, models.NewErrorResponse("Photo rejected — violates community guidelines"))
 				return
@@ -87,7 +101,8 @@ func (h *ProfileHandler) UpsertProfile(w http.ResponseWriter, r *http.Request) {
 			writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to process image"))
 			return
 		}
-		req.PhotoURL = &res.ApprovedURL
+		photoURL := res.URL()
+		req.PhotoURL = &photoURL
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
@@ -99,6 +114,10 @@ func (h *ProfileHandler) UpsertProfile(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to update profile"))
 		return
 	}
+	h.textModeration.Apply(ctx, text, userID, models.ModerationTargetProfile, userID)
+	if text.Held() {
+		prof.Hidden = true
+	}
 	writeJSON(w, http.StatusOK, models.NewSuccessResponse(prof))
 }
 
@@ -120,6 +139,10 @@ func (h *ProfileHandler) GetPublicProfileByUserID(w http.ResponseWriter, r *http
 	defer cancel()
 
 	prof, err := h.profiles.GetByUserID(ctx, targetID)
+	if err == nil && prof.Hidden && targetID != userID {
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Profile not found"))
+		return
+	}
 	if err != nil {
 		// Fallback: if no Mongo profile exists yet, try Firebase Auth user record.
 		if h.authClient == nil {
@@ -137,6 +160,7 @@ func (h *ProfileHandler) GetPublicProfileByUserID(w http.ResponseWriter, r *http
 			DisplayName: u.DisplayName,
 			PhotoURL:    u.PhotoURL,
 		}
+		h.fillPublicStats(&pub)
 		writeJSON(w, http.StatusOK, models.NewSuccessResponse(pub))
 		return
 	}
@@ -162,5 +186,25 @@ func (h *ProfileHandler) GetPublicProfileByUserID(w http.ResponseWriter, r *http
 			}
 		}
 	}
+	h.fillPublicStats(&pub)
 	writeJSON(w, http.StatusOK, models.NewSuccessResponse(pub))
 }
+
+// fillPublicStats attaches the seller's aggregate review rating and follow counts (best effort).
+func (h *ProfileHandler) fillPublicStats(pub *models.PublicProfile) {
+	if h.reviews != nil {
+		if rating, err := h.reviews.GetSellerRating(pub.UserID); err != nil {
+			log.Printf("[GetPublicProfileByUserID] rating user=%s error=%v", pub.UserID, err)
+		} else {
+			pub.Rating = *rating
+		}
+	}
+	if h.follows != nil {
+		if followers, following, err := h.follows.GetFollowCounts(pub.UserID); err != nil {
+			log.Printf("[GetPublicProfileByUserID] follow counts user=%s error=%v", pub.UserID, err)
+		} else {
+			pub.FollowerCount = followers
+			pub.FollowingCount = following
+		}
+	}
+}
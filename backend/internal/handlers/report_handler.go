@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+// ReportHandler lets users report sales, items and profiles, and admins read the
+// reports behind a queue entry.
+type ReportHandler struct {
+	reports services.ReportService
+	intake  *services.ReportIntake
+}
+
+func NewReportHandler(reports services.ReportService, intake *services.ReportIntake) *ReportHandler {
+	return &ReportHandler{
+		reports: reports,
+		intake:  intake,
+	}
+}
+
+func (h *ReportHandler) CreateReport(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.CreateReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	report, err := h.intake.File(r.Context(), userID, &req)
+	if err != nil {
+		log.Printf("[CreateReport] user=%s target=%s/%s error=%v", userID, req.TargetType, req.TargetID, err)
+		writeReportError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(&models.ReportReceipt{
+		Report:  report,
+		Message: services.ReportAcknowledgement(report.TargetType),
+	}))
+}
+
+// ListReports lists reports for admins (query: target_type and target_id, as on the
+// queue entry; limit), oldest first.
+func (h *ReportHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	targetType, targetID := q.Get("target_type"), q.Get("target_id")
+	if !models.IsModerationTarget(targetType) || targetID == "" {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("target_type and target_id are required"))
+		return
+	}
+
+	reports, err := h.reports.ListByTarget(targetType, targetID, parseLimit(r, 100))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list reports"))
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(reports))
+}
+
+func writeReportError(w http.ResponseWriter, err error) {
+	switch err {
+	case services.ErrReportExists:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("You have already reported this"))
+	case services.ErrReportSelf:
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("You can't report your own content"))
+	case services.ErrReportTargetNotFound:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Reported content not found"))
+	case services.ErrModerationBadInput:
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request"))
+	default:
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to file report"))
+	}
+}
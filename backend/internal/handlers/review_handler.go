@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+type ReviewHandler struct {
+	reviewService  services.ReviewService
+	textModeration *services.TextModerationService
+}
+
+func NewReviewHandler(reviewService services.ReviewService, textModeration *services.TextModerationService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService:  reviewService,
+		textModeration: textModeration,
+	}
+}
+
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	saleID := chi.URLParam(r, "saleId")
+
+	var req models.CreateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	text, ok := checkText(w, r, h.textModeration, userID, models.ModerationTargetReview, "", map[string]*string{
+		"comment": &req.Comment,
+	}, func() bool {
+		if err := h.reviewService.CanReview(userID, saleID); err != nil {
+			writeReviewError(w, userID, saleID, err)
+			return false
+		}
+		return true
+	})
+	if !ok {
+		return
+	}
+
+	review, err := h.reviewService.CreateReview(userID, saleID, &req)
+	if err != nil {
+		writeReviewError(w, userID, saleID, err)
+		return
+	}
+
+	h.textModeration.Apply(r.Context(), text, userID, models.ModerationTargetReview, review.ID)
+	if text.Held() {
+		review.Hidden = true
+	}
+	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(review))
+}
+
+func (h *ReviewHandler) ListSaleReviews(w http.ResponseWriter, r *http.Request) {
+	saleID := chi.URLParam(r, "saleId")
+
+	reviews, err := h.reviewService.ListSaleReviews(saleID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list reviews"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(reviews))
+}
+
+func (h *ReviewHandler) ListSellerReviews(w http.ResponseWriter, r *http.Request) {
+	sellerID := chi.URLParam(r, "userId")
+
+	reviews, err := h.reviewService.ListSellerReviews(sellerID, 100)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list reviews"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(reviews))
+}
+
+func (h *ReviewHandler) ReplyToReview(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	saleID := chi.URLParam(r, "saleId")
+	reviewID := chi.URLParam(r, "reviewId")
+
+	var req models.ReplyReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	text, ok := checkText(w, r, h.textModeration, userID, models.ModerationTargetReviewReply, reviewID, map[string]*string{
+		"body": &req.Body,
+	}, func() bool {
+		if err := h.reviewService.CanReply(userID, saleID, reviewID); err != nil {
+			writeReplyError(w, err)
+			return false
+		}
+		return true
+	})
+	if !ok {
+		return
+	}
+
+	review, err := h.reviewService.ReplyToReview(userID, saleID, reviewID, &req)
+	if err != nil {
+		writeReplyError(w, err)
+		return
+	}
+
+	h.textModeration.Apply(r.Context(), text, userID, models.ModerationTargetReviewReply, review.ID)
+	if text.Held() && review.Reply != nil {
+		review.Reply.Hidden = true
+	}
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(review))
+}
+
+// writeReviewError answers a failed CreateReview or CanReview.
+func writeReviewError(w http.ResponseWriter, userID, saleID string, err error) {
+	switch err {
+	case services.ErrReviewSaleNotFound:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Sale not found"))
+	case services.ErrAlreadyReviewed:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("You have already reviewed this sale"))
+	case services.ErrReviewOwnSale:
+		writeJSON(w, http.StatusForbidden, models.NewErrorResponse("You cannot review your own sale"))
+	case services.ErrReviewTooEarly:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("Reviews open once the sale has started"))
+	default:
+		log.Printf("[CreateReview] user=%s sale=%s error=%v", userID, saleID, err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to create review"))
+	}
+}
+
+// writeReplyError answers a failed ReplyToReview or CanReply.
+func writeReplyError(w http.ResponseWriter, err error) {
+	switch err {
+	case services.ErrReviewNotFound:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Review not found"))
+	case services.ErrReviewReplyDenied:
+		writeJSON(w, http.StatusForbidden, models.NewErrorResponse("Only the seller can reply to this review"))
+	default:
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to reply to review"))
+	}
+}
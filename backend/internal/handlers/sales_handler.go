@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
@@ -17,12 +18,18 @@ import (
 type SalesHandler struct {
 	salesService      services.SalesService
 	moderationService *services.ModerationService
+	textModeration    *services.TextModerationService
+	events            *services.SaleEventBus
+	notifications     services.NotificationPublisher
 }
 
-func NewSalesHandler(salesService services.SalesService, moderationService *services.ModerationService) *SalesHandler {
+func NewSalesHandler(salesService services.SalesService, moderationService *services.ModerationService, textModeration *services.TextModerationService, events *services.SaleEventBus, notifications services.NotificationPublisher) *SalesHandler {
 	return &SalesHandler{
 		salesService:      salesService,
 		moderationService: moderationService,
+		textModeration:    textModeration,
+		events:            events,
+		notifications:     notifications,
 	}
 }
 
@@ -47,6 +54,14 @@ func (h *SalesHandler) CreateSale(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	text, ok := checkText(w, r, h.textModeration, userID, models.ModerationTargetSale, "", map[string]*string{
+		"title":       &req.Title,
+		"description": &req.Description,
+	}, nil)
+	if !ok {
+		return
+	}
+
 	sale, err := h.salesService.Create(userID, &req)
 	if err != nil {
 		log.Printf("[CreateSale] Service error: %v", err)
@@ -55,9 +70,44 @@ func (h *SalesHandler) CreateSale(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Printf("[CreateSale] Sale created: %s", sale.ID)
+	h.textModeration.Apply(r.Context(), text, userID, models.ModerationTargetSale, sale.ID)
+	if text.Held() {
+		// Held for review: don't announce a sale nobody else can see yet.
+		sale.Hidden = true
+	} else {
+		h.events.Publish(services.SaleEvent{Type: services.SaleEventCreated, ActorID: userID, Sale: sale})
+	}
 	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(sale))
 }
 
+// canEdit checks that userID may edit the sale and, if itemID is set, that the item
+// is on it, answering 404 or 403 the way the edit itself would.
+func (h *SalesHandler) canEdit(w http.ResponseWriter, userID, saleID, itemID string) bool {
+	sale, err := h.salesService.GetByID(saleID)
+	if err != nil {
+		if err == services.ErrSaleNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Sale not found"))
+			return false
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to get sale"))
+		return false
+	}
+	if !sale.CanUser(userID, models.SaleActionEdit) {
+		writeJSON(w, http.StatusForbidden, models.NewErrorResponse("Not authorized to edit this sale"))
+		return false
+	}
+	if itemID == "" {
+		return true
+	}
+	for _, item := range sale.Items {
+		if item.ID == itemID {
+			return true
+		}
+	}
+	writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Item not found"))
+	return false
+}
+
 func (h *SalesHandler) GetSale(w http.ResponseWriter, r *http.Request) {
 	saleID := chi.URLParam(r, "saleId")
 
@@ -70,6 +120,13 @@ func (h *SalesHandler) GetSale(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to get sale"))
 		return
 	}
+	if !sale.CanUser(middleware.GetUserID(r.Context()), models.SaleActionRun) {
+		if sale.Hidden {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Sale not found"))
+			return
+		}
+		sale.Items = visibleItems(sale.Items)
+	}
 
 	writeJSON(w, http.StatusOK, models.NewSuccessResponse(sale))
 }
@@ -84,6 +141,20 @@ func (h *SalesHandler) UpdateSale(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	text, ok := checkText(w, r, h.textModeration, userID, models.ModerationTargetSale, saleID, map[string]*string{
+		"title":       &req.Title,
+		"description": &req.Description,
+	}, func() bool { return h.canEdit(w, userID, saleID, "") })
+	if !ok {
+		return
+	}
+
+	// Snapshot the current dates so a reschedule can be announced after the update.
+	var previous *models.GarageSale
+	if h.events != nil {
+		previous, _ = h.salesService.GetByID(saleID)
+	}
+
 	sale, err := h.salesService.Update(userID, saleID, &req)
 	if err != nil {
 		if err == services.ErrSaleNotFound {
@@ -98,6 +169,13 @@ func (h *SalesHandler) UpdateSale(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.textModeration.Apply(r.Context(), text, userID, models.ModerationTargetSale, saleID)
+	if text.Held() {
+		sale.Hidden = true
+	}
+	if previous != nil && (!previous.StartDate.Equal(sale.StartDate) || !previous.EndDate.Equal(sale.EndDate)) {
+		h.events.Publish(services.SaleEvent{Type: services.SaleEventRescheduled, ActorID: userID, Sale: sale, Previous: previous})
+	}
 	writeJSON(w, http.StatusOK, models.NewSuccessResponse(sale))
 }
 
@@ -113,8 +191,11 @@ func (h *SalesHandler) SetSaleCoverPhoto(w http.ResponseWriter, r *http.Request)
 
 	coverURL := req.SaleCoverPhoto
 	if h.moderationService != nil && strings.HasPrefix(coverURL, "pending/") {
-		res, err := h.moderationService.ModerateAndPromote(r.Context(), coverURL, userID)
+		res, err := h.moderationService.ModerateAndPromote(r.Context(), coverURL, userID, services.UploadTypeSaleCover)
 		if err != nil {
+			if writeRestricted(w, err) {
+				return
+			}
 			if err == services.ErrImageRejected {
 				writeJSON(w, http.StatusUnprocessableEntity, models.NewErrorResponse("Photo rejected — violates community guidelines"))
 				return
@@ -123,7 +204,7 @@ func (h *SalesHandler) SetSaleCoverPhoto(w http.ResponseWriter, r *http.Request)
 			writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to process image"))
 			return
 		}
-		coverURL = res.ApprovedURL
+		coverURL = res.URL()
 	}
 
 	sale, err := h.salesService.SetSaleCoverPhoto(userID, saleID, coverURL)
@@ -147,6 +228,12 @@ func (h *SalesHandler) DeleteSale(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r.Context())
 	saleID := chi.URLParam(r, "saleId")
 
+	// Keep the sale around so listeners can tell people what was cancelled.
+	var deleted *models.GarageSale
+	if h.events != nil {
+		deleted, _ = h.salesService.GetByID(saleID)
+	}
+
 	err := h.salesService.Delete(userID, saleID)
 	if err != nil {
 		if err == services.ErrSaleNotFound {
@@ -161,6 +248,8 @@ func (h *SalesHandler) DeleteSale(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.events.Publish(services.SaleEvent{Type: services.SaleEventDeleted, ActorID: userID, Sale: deleted})
+
 	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]string{"message": "Sale deleted successfully"}))
 }
 
@@ -182,6 +271,7 @@ func (h *SalesHandler) StartSale(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.events.Publish(services.SaleEvent{Type: services.SaleEventStarted, ActorID: userID, Sale: sale})
 	writeJSON(w, http.StatusOK, models.NewSuccessResponse(sale))
 }
 
@@ -328,9 +418,20 @@ func (h *SalesHandler) AddItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	text, ok := checkText(w, r, h.textModeration, userID, models.ModerationTargetItem, "", map[string]*string{
+		"item_name":        &req.Name,
+		"item_description": &req.Description,
+	}, func() bool { return h.canEdit(w, userID, saleID, "") })
+	if !ok {
+		return
+	}
+
 	if h.moderationService != nil && len(req.ImageURLs) > 0 {
-		approved, err := h.moderationService.ModerateMultiple(r.Context(), req.ImageURLs, userID)
+		approved, err := h.moderationService.ModerateMultiple(r.Context(), req.ImageURLs, userID, services.UploadTypeSaleItem)
 		if err != nil {
+			if writeRestricted(w, err) {
+				return
+			}
 			if err == services.ErrImageRejected {
 				writeJSON(w, http.StatusUnprocessableEntity, models.NewErrorResponse("Photo rejected — violates community guidelines"))
 				return
@@ -356,6 +457,12 @@ func (h *SalesHandler) AddItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.textModeration.Apply(r.Context(), text, userID, models.ModerationTargetItem, models.ItemTargetID(saleID, item.ID))
+	if text.Held() {
+		item.Hidden = true
+	} else {
+		h.publishItemEvent(services.SaleEventItemAdded, userID, saleID, item)
+	}
 	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(item))
 }
 
@@ -375,9 +482,20 @@ func (h *SalesHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	text, ok := checkText(w, r, h.textModeration, userID, models.ModerationTargetItem, models.ItemTargetID(saleID, itemID), map[string]*string{
+		"item_name":        &req.Name,
+		"item_description": &req.Description,
+	}, func() bool { return h.canEdit(w, userID, saleID, itemID) })
+	if !ok {
+		return
+	}
+
 	if h.moderationService != nil && len(req.ImageURLs) > 0 {
-		approved, err := h.moderationService.ModerateMultiple(r.Context(), req.ImageURLs, userID)
+		approved, err := h.moderationService.ModerateMultiple(r.Context(), req.ImageURLs, userID, services.UploadTypeSaleItem)
 		if err != nil {
+			if writeRestricted(w, err) {
+				return
+			}
 			if err == services.ErrImageRejected {
 				writeJSON(w, http.StatusUnprocessableEntity, models.NewErrorResponse("Photo rejected — violates community guidelines"))
 				return
@@ -407,6 +525,12 @@ func (h *SalesHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.textModeration.Apply(r.Context(), text, userID, models.ModerationTargetItem, models.ItemTargetID(saleID, item.ID))
+	if text.Held() {
+		item.Hidden = true
+	} else {
+		h.publishItemEvent(services.SaleEventItemUpdated, userID, saleID, item)
+	}
 	writeJSON(w, http.StatusOK, models.NewSuccessResponse(item))
 }
 
@@ -435,3 +559,144 @@ func (h *SalesHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]string{"message": "Item deleted successfully"}))
 }
+
+func (h *SalesHandler) ListCoHostedSales(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	sales, err := h.salesService.ListCoHostedByUser(userID, 200)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list co-hosted sales"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(sales))
+}
+
+func (h *SalesHandler) InviteCoHost(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+	saleID := chi.URLParam(r, "saleId")
+
+	var req models.InviteCoHostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	sale, err := h.salesService.InviteCoHost(userID, saleID, &req)
+	if err != nil {
+		writeCoHostError(w, err, "Not authorized to manage co-hosts for this sale")
+		return
+	}
+
+	if c := sale.CoHost(req.UserID); c != nil && c.Status == models.CoHostStatusInvited && h.notifications != nil {
+		n := &models.Notification{
+			UserID: req.UserID,
+			Type:   models.NotificationCoHostInvited,
+			Title:  "You're invited to co-host a sale",
+			Body:   fmt.Sprintf("Help run %s as %s", sale.Title, req.Role),
+			Data:   map[string]string{"sale_id": sale.ID, "role": req.Role},
+		}
+		if err := h.notifications.Publish(r.Context(), n); err != nil {
+			log.Printf("[InviteCoHost] notify user=%s sale=%s err=%v", req.UserID, sale.ID, err)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(sale))
+}
+
+func (h *SalesHandler) AcceptCoHostInvite(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+	saleID := chi.URLParam(r, "saleId")
+
+	sale, err := h.salesService.AcceptCoHostInvite(userID, saleID)
+	if err != nil {
+		writeCoHostError(w, err, "Not authorized to accept this invite")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(sale))
+}
+
+// RemoveCoHost lets the owner remove a co-host, or a co-host decline or leave.
+func (h *SalesHandler) RemoveCoHost(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+	saleID := chi.URLParam(r, "saleId")
+	coHostID := chi.URLParam(r, "userId")
+
+	sale, err := h.salesService.RemoveCoHost(userID, saleID, coHostID)
+	if err != nil {
+		writeCoHostError(w, err, "Not authorized to remove this co-host")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(sale))
+}
+
+// writeCoHostError maps co-host service errors to responses.
+func writeCoHostError(w http.ResponseWriter, err error, forbidden string) {
+	switch err {
+	case services.ErrSaleNotFound:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Sale not found"))
+	case services.ErrCoHostNotFound:
+		writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Co-host not found"))
+	case services.ErrUnauthorized:
+		writeJSON(w, http.StatusForbidden, models.NewErrorResponse(forbidden))
+	case services.ErrCoHostExists:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("User is already a co-host of this sale"))
+	case services.ErrCoHostLimit:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("Co-host limit reached"))
+	case services.ErrCoHostConflict:
+		writeJSON(w, http.StatusConflict, models.NewErrorResponse("Co-hosts changed, please try again"))
+	case services.ErrCoHostSelf:
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("The owner cannot be a co-host"))
+	default:
+		log.Printf("[CoHosts] error=%v", err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to update co-hosts"))
+	}
+}
+
+// publishItemEvent emits an item event. Item listeners need the sale for its location,
+// so it is looked up here; failures only skip the event.
+func (h *SalesHandler) publishItemEvent(typ services.SaleEventType, userID, saleID string, item *models.Item) {
+	if h.events == nil {
+		return
+	}
+	sale, err := h.salesService.GetByID(saleID)
+	if err != nil {
+		log.Printf("[SaleEvents] lookup sale=%s for %s failed: %v", saleID, typ, err)
+		return
+	}
+	h.events.Publish(services.SaleEvent{Type: typ, ActorID: userID, Sale: sale, Item: item})
+}
+
+// visibleItems drops items hidden by moderation for anyone but the sale's team.
+func visibleItems(items []models.Item) []models.Item {
+	out := items[:0]
+	for _, item := range items {
+		if !item.Hidden {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+type SavedSearchHandler struct {
+	savedSearchService services.SavedSearchService
+}
+
+func NewSavedSearchHandler(savedSearchService services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		savedSearchService: savedSearchService,
+	}
+}
+
+func (h *SavedSearchHandler) CreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.CreateSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	search, err := h.savedSearchService.CreateSavedSearch(userID, &req)
+	if err != nil {
+		if err == services.ErrSavedSearchLimit {
+			writeJSON(w, http.StatusConflict, models.NewErrorResponse("Saved search limit reached"))
+			return
+		}
+		log.Printf("[CreateSavedSearch] user=%s error=%v", userID, err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to save search"))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(search))
+}
+
+func (h *SavedSearchHandler) ListSavedSearches(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	searches, err := h.savedSearchService.ListSavedSearches(userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list saved searches"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(searches))
+}
+
+func (h *SavedSearchHandler) DeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	searchID := chi.URLParam(r, "searchId")
+
+	err := h.savedSearchService.DeleteSavedSearch(userID, searchID)
+	if err != nil {
+		if err == services.ErrSavedSearchNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Saved search not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to delete saved search"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]string{"message": "Saved search deleted successfully"}))
+}
+
+func (h *SavedSearchHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	alerts, err := h.savedSearchService.ListAlerts(userID, 200)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list alerts"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(alerts))
+}
@@ -7,29 +7,63 @@ import (
 	"net"
 	"net/http"
 	"net/mail"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	"github.com/rummage/backend/internal/middleware"
 	"github.com/rummage/backend/internal/models"
 	"github.com/rummage/backend/internal/services"
 )
 
 type SupportHandler struct {
-	recaptcha *services.RecaptchaVerifier
-	mailer    *services.SendGridMailer
+	captcha   services.CaptchaVerifier
+	mailer    services.Mailer
+	tickets   services.SupportTicketService
+	sales     services.SalesService
+	addresses services.EmailAddressResolver
+	supportTo string
+	statusURL string
 }
 
-func NewSupportHandler(recaptcha *services.RecaptchaVerifier, mailer *services.SendGridMailer) *SupportHandler {
-	return &SupportHandler{recaptcha: recaptcha, mailer: mailer}
+// NewSupportHandler stores support requests as tickets and emails them to supportTo
+// through mailer. statusURL is the optional web page submitters use to look up a ticket;
+// the ticket number and secret are appended as query parameters. sales and addresses
+// fill in context for in-app requests.
+func NewSupportHandler(
+	captcha services.CaptchaVerifier,
+	mailer services.Mailer,
+	tickets services.SupportTicketService,
+	sales services.SalesService,
+	addresses services.EmailAddressResolver,
+	supportTo string,
+	statusURL string,
+) *SupportHandler {
+	to := strings.TrimSpace(supportTo)
+	if to == "" {
+		to = "support@ludicrousapps.io"
+	}
+	return &SupportHandler{
+		captcha:   captcha,
+		mailer:    mailer,
+		tickets:   tickets,
+		sales:     sales,
+		addresses: addresses,
+		supportTo: to,
+		statusURL: strings.TrimSpace(statusURL),
+	}
 }
 
 type supportRequestBody struct {
 	Name           string `json:"name"`
 	Email          string `json:"email"`
 	Message        string `json:"message"`
-	RecaptchaToken string `json:"recaptchaToken"`
+	CaptchaToken   string `json:"captchaToken"`
+	RecaptchaToken string `json:"recaptchaToken"` // older website builds
 }
 
 func (h *SupportHandler) SubmitSupportRequest(w http.ResponseWriter, r *http.Request) {
@@ -42,7 +76,10 @@ func (h *SupportHandler) SubmitSupportRequest(w http.ResponseWriter, r *http.Req
 	name := strings.TrimSpace(req.Name)
 	email := strings.TrimSpace(req.Email)
 	msg := strings.TrimSpace(req.Message)
-	token := strings.TrimSpace(req.RecaptchaToken)
+	token := strings.TrimSpace(req.CaptchaToken)
+	if token == "" {
+		token = strings.TrimSpace(req.RecaptchaToken)
+	}
 
 	errors := map[string]string{}
 	if name == "" {
@@ -66,7 +103,9 @@ func (h *SupportHandler) SubmitSupportRequest(w http.ResponseWriter, r *http.Req
 	}
 
 	if token == "" {
-		errors["recaptchaToken"] = "reCAPTCHA token is required"
+		// Older website builds map field errors by recaptchaToken.
+		errors["captchaToken"] = "CAPTCHA token is required"
+		errors["recaptchaToken"] = "CAPTCHA token is required"
 	}
 
 	if len(errors) > 0 {
@@ -79,30 +118,342 @@ func (h *SupportHandler) SubmitSupportRequest(w http.ResponseWriter, r *http.Req
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
-	ok, reason, err := h.recaptcha.VerifyV2(ctx, token, remoteIP)
+	ok, reason, err := h.captcha.Verify(ctx, token, remoteIP)
 	if err != nil {
-		log.Printf("[Support] recaptcha error ip=%s err=%v", remoteIP, err)
-		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to verify reCAPTCHA"))
+		log.Printf("[Support] captcha error ip=%s err=%v", remoteIP, err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to verify CAPTCHA"))
 		return
 	}
 	if !ok {
-		log.Printf("[Support] recaptcha failed ip=%s reason=%s", remoteIP, reason)
-		writeJSON(w, http.StatusForbidden, models.NewErrorResponse("reCAPTCHA verification failed"))
+		log.Printf("[Support] captcha failed ip=%s reason=%s", remoteIP, reason)
+		writeJSON(w, http.StatusForbidden, models.NewErrorResponse("CAPTCHA verification failed"))
 		return
 	}
 
-	ticket := generateSupportTicket()
-	if err := h.mailer.SendSupportEmail(ctx, ticket, name, email, msg); err != nil {
-		log.Printf("[Support] ticket=%s sendgrid error=%v", ticket, err)
-		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to send support request"))
+	ticket := &models.SupportTicket{
+		ID:      generateSupportTicket(),
+		Name:    name,
+		Email:   email,
+		Message: msg,
+	}
+	secret, err := h.tickets.CreateTicket(ticket)
+	if err != nil {
+		log.Printf("[Support] ticket=%s save error=%v", ticket.ID, err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to save support request"))
 		return
 	}
 
+	// The ticket is stored, so mail failures are logged rather than failing the request.
+	h.sendTicketEmails(ctx, ticket, secret)
+
 	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]string{
-		"ticket": ticket,
+		"ticket": ticket.ID,
 	}))
 }
 
+// SubmitInAppSupportRequest takes a support request from a signed-in user. It skips the
+// CAPTCHA, takes the submitter from the auth token and attaches the listing and device
+// context the app sends.
+func (h *SupportHandler) SubmitInAppSupportRequest(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.CreateInAppSupportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	email := middleware.GetUserEmail(r.Context())
+	var name string
+	if h.addresses != nil {
+		if resolved, resolvedName, err := h.addresses.EmailForUser(ctx, userID); err == nil {
+			if email == "" {
+				email = resolved
+			}
+			name = resolvedName
+		}
+	}
+	if email == "" {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Add an email address to your account so support can reply"))
+		return
+	}
+
+	supportCtx, errors := h.buildSupportContext(&req)
+	if len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	ticket := &models.SupportTicket{
+		ID:      generateSupportTicket(),
+		UserID:  userID,
+		Name:    name,
+		Email:   email,
+		Subject: strings.TrimSpace(req.Subject),
+		Message: strings.TrimSpace(req.Message),
+		Context: supportCtx,
+	}
+	secret, err := h.tickets.CreateTicket(ticket)
+	if err != nil {
+		log.Printf("[Support] ticket=%s user=%s save error=%v", ticket.ID, userID, err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to save support request"))
+		return
+	}
+
+	h.sendTicketEmails(ctx, ticket, secret)
+
+	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(map[string]string{
+		"ticket": ticket.ID,
+	}))
+}
+
+// buildSupportContext resolves the attached sale and items so the ticket records what
+// the user was looking at. Unknown IDs are validation errors.
+func (h *SupportHandler) buildSupportContext(req *models.CreateInAppSupportRequest) (*models.SupportContext, map[string]string) {
+	c := &models.SupportContext{
+		AppVersion:  strings.TrimSpace(req.AppVersion),
+		Platform:    strings.TrimSpace(req.Platform),
+		OSVersion:   strings.TrimSpace(req.OSVersion),
+		DeviceModel: strings.TrimSpace(req.DeviceModel),
+	}
+
+	saleID := strings.TrimSpace(req.SaleID)
+	if saleID != "" && h.sales != nil {
+		sale, err := h.sales.GetByID(saleID)
+		if err != nil {
+			return nil, map[string]string{"sale_id": "Sale not found"}
+		}
+		c.SaleID = sale.ID
+		c.SaleTitle = sale.Title
+		c.SellerID = sale.UserID
+
+		names := make(map[string]string, len(sale.Items))
+		for _, item := range sale.Items {
+			names[item.ID] = item.Name
+		}
+		for _, itemID := range req.ItemIDs {
+			name, ok := names[itemID]
+			if !ok {
+				return nil, map[string]string{"item_ids": "Item " + itemID + " is not part of this sale"}
+			}
+			c.ItemIDs = append(c.ItemIDs, itemID)
+			c.ItemNames = append(c.ItemNames, name)
+		}
+	}
+
+	if c.SaleID == "" && c.AppVersion == "" && c.Platform == "" && c.OSVersion == "" && c.DeviceModel == "" {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// GetTicketStatus is the public ticket lookup (query: secret). Unknown tickets and wrong
+// secrets both return 404.
+func (h *SupportHandler) GetTicketStatus(w http.ResponseWriter, r *http.Request) {
+	ticketID := chi.URLParam(r, "ticket")
+	secret := strings.TrimSpace(r.URL.Query().Get("secret"))
+
+	ticket, err := h.tickets.GetTicketWithSecret(ticketID, secret)
+	if err != nil {
+		if err == services.ErrSupportTicketNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Ticket not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to get ticket"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(ticket.PublicStatus()))
+}
+
+// ListTickets is admin-only (query: status, limit).
+func (h *SupportHandler) ListTickets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	status := query.Get("status")
+	if status != "" && !models.IsSupportTicketStatus(status) {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid status"))
+		return
+	}
+
+	limit := 100
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		if v, err := strconv.Atoi(rawLimit); err == nil && v > 0 {
+			limit = v
+		}
+	}
+
+	tickets, err := h.tickets.ListTickets(status, limit)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list tickets"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(tickets))
+}
+
+// GetTicket is admin-only.
+func (h *SupportHandler) GetTicket(w http.ResponseWriter, r *http.Request) {
+	ticket, err := h.tickets.GetTicket(chi.URLParam(r, "ticket"))
+	if err != nil {
+		if err == services.ErrSupportTicketNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Ticket not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to get ticket"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(ticket))
+}
+
+// ReplyToTicket is admin-only. The reply is stored on the ticket and emailed to the submitter.
+func (h *SupportHandler) ReplyToTicket(w http.ResponseWriter, r *http.Request) {
+	adminID := middleware.GetUserID(r.Context())
+	if adminID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	ticketID := chi.URLParam(r, "ticket")
+
+	var req models.ReplySupportTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	ticket, err := h.tickets.AddReply(ticketID, adminID, &req)
+	if err != nil {
+		if err == services.ErrSupportTicketNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Ticket not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to reply to ticket"))
+		return
+	}
+
+	msg, err := services.RenderEmail("support_reply", services.SupportReplyEmail{
+		Ticket: ticket.ID,
+		Name:   ticket.Name,
+		Reply:  strings.TrimSpace(req.Body),
+		Status: ticket.Status,
+	})
+	if err == nil {
+		msg.To = ticket.Email
+		msg.ToName = ticket.Name
+		msg.ReplyTo = h.supportTo
+		msg.FromName = "Rummage Support"
+		msg.Tags = map[string]string{"ticket": ticket.ID}
+		err = h.mailer.Send(r.Context(), msg)
+	}
+	if err != nil {
+		log.Printf("[Support] ticket=%s reply mail error=%v", ticket.ID, err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Reply saved but the email could not be queued"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(ticket))
+}
+
+// UpdateTicketStatus is admin-only.
+func (h *SupportHandler) UpdateTicketStatus(w http.ResponseWriter, r *http.Request) {
+	ticketID := chi.URLParam(r, "ticket")
+
+	var req models.UpdateSupportTicketStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	ticket, err := h.tickets.UpdateStatus(ticketID, req.Status)
+	if err != nil {
+		if err == services.ErrSupportTicketNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Ticket not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to update ticket"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(ticket))
+}
+
+// sendTicketEmails queues the ticket for the support inbox and sends the submitter
+// their lookup secret.
+func (h *SupportHandler) sendTicketEmails(ctx context.Context, ticket *models.SupportTicket, secret string) {
+	msg, err := services.RenderEmail("support_request", services.SupportRequestEmail{
+		Ticket:  ticket.ID,
+		Name:    ticket.Name,
+		Email:   ticket.Email,
+		UserID:  ticket.UserID,
+		Subject: ticket.Subject,
+		Message: ticket.Message,
+		Context: ticket.Context,
+	})
+	if err == nil {
+		msg.To = h.supportTo
+		msg.ReplyTo = ticket.Email
+		msg.ReplyToName = ticket.Name
+		msg.FromName = "Rummage Support Form"
+		msg.Tags = map[string]string{"ticket": ticket.ID}
+		err = h.mailer.Send(ctx, msg)
+	}
+	if err != nil {
+		log.Printf("[Support] ticket=%s inbox mail error=%v", ticket.ID, err)
+	}
+
+	confirmation := services.SupportConfirmationEmail{
+		Ticket: ticket.ID,
+		Name:   ticket.Name,
+		Secret: secret,
+	}
+	if h.statusURL != "" {
+		q := url.Values{}
+		q.Set("ticket", ticket.ID)
+		q.Set("secret", secret)
+		sep := "?"
+		if strings.Contains(h.statusURL, "?") {
+			sep = "&"
+		}
+		confirmation.StatusURL = h.statusURL + sep + q.Encode()
+	}
+	msg, err = services.RenderEmail("support_confirmation", confirmation)
+	if err == nil {
+		msg.To = ticket.Email
+		msg.ToName = ticket.Name
+		msg.ReplyTo = h.supportTo
+		msg.FromName = "Rummage Support"
+		msg.Tags = map[string]string{"ticket": ticket.ID}
+		err = h.mailer.Send(ctx, msg)
+	}
+	if err != nil {
+		log.Printf("[Support] ticket=%s confirmation mail error=%v", ticket.ID, err)
+	}
+}
+
 func generateSupportTicket() string {
 	// Example: RM-20260131-032508-A1B2C3D4
 	now := time.Now().UTC().Format("20060102-150405")
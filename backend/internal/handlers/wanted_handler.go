@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rummage/backend/internal/middleware"
+	"github.com/rummage/backend/internal/models"
+	"github.com/rummage/backend/internal/services"
+)
+
+type WantedHandler struct {
+	wantedService services.WantedService
+}
+
+func NewWantedHandler(wantedService services.WantedService) *WantedHandler {
+	return &WantedHandler{
+		wantedService: wantedService,
+	}
+}
+
+func (h *WantedHandler) CreateWanted(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	var req models.CreateWantedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid request body"))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		writeJSON(w, http.StatusBadRequest, models.NewValidationErrorResponse(errors))
+		return
+	}
+
+	post, err := h.wantedService.CreateWanted(userID, &req)
+	if err != nil {
+		if err == services.ErrWantedLimit {
+			writeJSON(w, http.StatusConflict, models.NewErrorResponse("Wanted post limit reached"))
+			return
+		}
+		log.Printf("[CreateWanted] user=%s error=%v", userID, err)
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to create wanted post"))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, models.NewSuccessResponse(post))
+}
+
+func (h *WantedHandler) ListWanted(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	posts, err := h.wantedService.ListWanted(userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list wanted posts"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(posts))
+}
+
+// ListNearby lets sellers browse what buyers around a point are looking for.
+func (h *WantedHandler) ListNearby(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	query := r.URL.Query()
+	latStr := query.Get("lat")
+	lngStr := query.Get("lng")
+	if latStr == "" || lngStr == "" {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Missing required parameters: lat, lng"))
+		return
+	}
+
+	lat, err1 := strconv.ParseFloat(latStr, 64)
+	lng, err2 := strconv.ParseFloat(lngStr, 64)
+	if err1 != nil || err2 != nil {
+		writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid lat/lng"))
+		return
+	}
+
+	posts, err := h.wantedService.ListNearby(userID, lat, lng, 100)
+	if err != nil {
+		if err == services.ErrWantedBadInput {
+			writeJSON(w, http.StatusBadRequest, models.NewErrorResponse("Invalid lat/lng"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list wanted posts"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(posts))
+}
+
+func (h *WantedHandler) ListMatches(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	matches, err := h.wantedService.ListMatches(userID, 200)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to list matches"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(matches))
+}
+
+func (h *WantedHandler) DeleteWanted(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+		return
+	}
+
+	wantedID := chi.URLParam(r, "wantedId")
+
+	err := h.wantedService.DeleteWanted(userID, wantedID)
+	if err != nil {
+		if err == services.ErrWantedNotFound {
+			writeJSON(w, http.StatusNotFound, models.NewErrorResponse("Wanted post not found"))
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, models.NewErrorResponse("Failed to delete wanted post"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewSuccessResponse(map[string]string{"message": "Wanted post deleted successfully"}))
+}
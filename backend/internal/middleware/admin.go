@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+const UserAdminKey contextKey = "userAdmin"
+
+// IsAdmin reports whether the authenticated user's token carries the "admin" custom claim.
+func IsAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(UserAdminKey).(bool)
+	return admin
+}
+
+// RequireAdmin allows users with the "admin" custom claim, or whose UID is listed in
+// adminUIDs, and rejects everyone else with 403. It must run after FirebaseAuth.
+func RequireAdmin(adminUIDs []string) func(http.Handler) http.Handler {
+	allowed := make(map[string]struct{}, len(adminUIDs))
+	for _, uid := range adminUIDs {
+		if uid != "" {
+			allowed[uid] = struct{}{}
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := GetUserID(r.Context())
+			if userID == "" {
+				writeJSON(w, http.StatusUnauthorized, models.NewErrorResponse("Unauthorized"))
+				return
+			}
+			if _, ok := allowed[userID]; !ok && !IsAdmin(r.Context()) {
+				writeJSON(w, http.StatusForbidden, models.NewErrorResponse("Admin access required"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -80,9 +80,11 @@ func FirebaseAuth(authClient *fbauth.Client) func(http.Handler) http.Handler {
 
 			userID := token.UID
 			email, _ := token.Claims["email"].(string)
+			admin, _ := token.Claims["admin"].(bool)
 
 			ctx := context.WithValue(r.Context(), UserIDKey, userID)
 			ctx = context.WithValue(ctx, UserEmailKey, email)
+			ctx = context.WithValue(ctx, UserAdminKey, admin)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+// RestrictionChecker looks up what a user's moderation strikes currently block.
+type RestrictionChecker interface {
+	Restrictions(userID string) (*models.UserRestrictions, error)
+}
+
+// Restrict refuses requests from users blocked by code (models.Restriction*) with
+// 403 and the blocking code in the response. Suspended users are refused everything
+// except reads, so they can still see their content and moderation history. Lookup
+// failures let the request through. It must run after FirebaseAuth.
+func Restrict(checker RestrictionChecker, code string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := GetUserID(r.Context())
+			if checker == nil || userID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if code == models.RestrictionAccountSuspended && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			res, err := checker.Restrictions(userID)
+			if err != nil {
+				log.Printf("[Restrict] lookup failed userID=%s err=%v", userID, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if blocking := res.Blocking(code); blocking != "" {
+				writeJSON(w, http.StatusForbidden, models.NewCodedErrorResponse(blocking, models.RestrictionMessage(blocking, res.SuspendedUntil)))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
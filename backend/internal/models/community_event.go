@@ -0,0 +1,158 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Membership statuses for a sale in a community event.
+const (
+	EventMembershipPending  = "pending"
+	EventMembershipApproved = "approved"
+	EventMembershipRejected = "rejected"
+)
+
+// maxEventBoundaryPoints bounds the size of an event's map polygon.
+const maxEventBoundaryPoints = 200
+
+// GeoPoint is a single latitude/longitude pair.
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// CommunityEvent groups many sales, e.g. a neighborhood-wide sale weekend, under one
+// organizer. Sellers inside the boundary ask to join and the organizer approves them.
+type CommunityEvent struct {
+	ID          string     `json:"id"`
+	OrganizerID string     `json:"organizer_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Boundary    []GeoPoint `json:"boundary"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Contains reports whether the point lies inside the event's boundary polygon.
+func (e *CommunityEvent) Contains(lat, lng float64) bool {
+	inside := false
+	n := len(e.Boundary)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		a, b := e.Boundary[i], e.Boundary[j]
+		if (a.Latitude > lat) != (b.Latitude > lat) &&
+			lng < (b.Longitude-a.Longitude)*(lat-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Bounds returns the boundary's bounding box.
+func (e *CommunityEvent) Bounds() (minLat, maxLat, minLng, maxLng float64) {
+	for i, p := range e.Boundary {
+		if i == 0 || p.Latitude < minLat {
+			minLat = p.Latitude
+		}
+		if i == 0 || p.Latitude > maxLat {
+			maxLat = p.Latitude
+		}
+		if i == 0 || p.Longitude < minLng {
+			minLng = p.Longitude
+		}
+		if i == 0 || p.Longitude > maxLng {
+			maxLng = p.Longitude
+		}
+	}
+	return minLat, maxLat, minLng, maxLng
+}
+
+// EventMembership records a sale's request to join an event and the organizer's decision.
+type EventMembership struct {
+	EventID     string     `json:"event_id"`
+	SaleID      string     `json:"sale_id"`
+	SellerID    string     `json:"seller_id"`
+	Status      string     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+}
+
+// CommunityEventPage is the event page: the event and its approved member sales.
+type CommunityEventPage struct {
+	Event *CommunityEvent `json:"event"`
+	Sales []*GarageSale   `json:"sales"`
+}
+
+// EventCluster summarises an event for the map so its member sales can be drawn as one
+// cluster.
+type EventCluster struct {
+	EventID   string    `json:"event_id"`
+	Title     string    `json:"title"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	MinLat    float64   `json:"min_lat"`
+	MaxLat    float64   `json:"max_lat"`
+	MinLng    float64   `json:"min_lng"`
+	MaxLng    float64   `json:"max_lng"`
+	SaleCount int       `json:"sale_count"`
+	SaleIDs   []string  `json:"sale_ids"`
+}
+
+type CreateCommunityEventRequest struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Boundary    []GeoPoint `json:"boundary"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+}
+
+func (r *CreateCommunityEventRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	title := strings.TrimSpace(r.Title)
+	if title == "" {
+		errors["title"] = "Title is required"
+	} else if len(title) > 120 {
+		errors["title"] = "Title is too long"
+	}
+	if len(r.Description) > 2000 {
+		errors["description"] = "Description is too long"
+	}
+	if len(r.Boundary) < 3 {
+		errors["boundary"] = "Boundary needs at least 3 points"
+	} else if len(r.Boundary) > maxEventBoundaryPoints {
+		errors["boundary"] = "Boundary has too many points"
+	} else {
+		for _, p := range r.Boundary {
+			if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude > 180 {
+				errors["boundary"] = "Boundary has an invalid coordinate"
+				break
+			}
+		}
+	}
+	if r.StartDate.IsZero() {
+		errors["start_date"] = "Start date is required"
+	}
+	if r.EndDate.IsZero() {
+		errors["end_date"] = "End date is required"
+	}
+	if !r.EndDate.IsZero() && !r.StartDate.IsZero() && r.EndDate.Before(r.StartDate) {
+		errors["end_date"] = "End date must be after start date"
+	}
+
+	return errors
+}
+
+type JoinCommunityEventRequest struct {
+	SaleID string `json:"sale_id"`
+}
+
+func (r *JoinCommunityEventRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+	if strings.TrimSpace(r.SaleID) == "" {
+		errors["sale_id"] = "Sale is required"
+	}
+	return errors
+}
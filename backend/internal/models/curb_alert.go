@@ -0,0 +1,69 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultCurbAlertHours is how long a curb alert stays up when the poster doesn't say.
+	DefaultCurbAlertHours = 4
+	// MaxCurbAlertHours caps a curb alert's lifetime; curbside items rarely last longer.
+	MaxCurbAlertHours = 12
+)
+
+// CurbAlert is a free item left at the curb. It is shown on the map alongside sales but
+// stored separately and expires on its own after a few hours.
+type CurbAlert struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	PhotoURL    string     `json:"photo_url"`
+	Latitude    float64    `json:"latitude"`
+	Longitude   float64    `json:"longitude"`
+	Address     string     `json:"address,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	GoneAt      *time.Time `json:"gone_at,omitempty"`
+}
+
+// Gone reports whether someone has marked the item as taken.
+func (c *CurbAlert) Gone() bool {
+	return c.GoneAt != nil
+}
+
+type CreateCurbAlertRequest struct {
+	Title          string  `json:"title"`
+	Description    string  `json:"description"`
+	PhotoURL       string  `json:"photo_url"`
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	Address        string  `json:"address"`
+	ExpiresInHours int     `json:"expires_in_hours"`
+}
+
+func (r *CreateCurbAlertRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	title := strings.TrimSpace(r.Title)
+	if title == "" {
+		errors["title"] = "Title is required"
+	} else if len(title) > 100 {
+		errors["title"] = "Title is too long"
+	}
+	if len(r.Description) > 500 {
+		errors["description"] = "Description is too long"
+	}
+	if strings.TrimSpace(r.PhotoURL) == "" {
+		errors["photo_url"] = "A photo is required"
+	}
+	if r.Latitude == 0 && r.Longitude == 0 {
+		errors["location"] = "Location coordinates are required"
+	}
+	if r.ExpiresInHours < 0 || r.ExpiresInHours > MaxCurbAlertHours {
+		errors["expires_in_hours"] = "Expiry must be between 1 and 12 hours"
+	}
+
+	return errors
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// DeviceToken is a push-notification registration token for one of a user's devices.
+type DeviceToken struct {
+	Token     string    `json:"token"`
+	UserID    string    `json:"user_id"`
+	Platform  string    `json:"platform"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type RegisterDeviceRequest struct {
+	Token    string `json:"token"`
+	Platform string `json:"platform"` // "ios", "android" or "web"
+}
+
+func (r *RegisterDeviceRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	token := strings.TrimSpace(r.Token)
+	if token == "" {
+		errors["token"] = "Token is required"
+	} else if len(token) > 4096 {
+		errors["token"] = "Token is too long"
+	}
+	switch r.Platform {
+	case "ios", "android", "web":
+	default:
+		errors["platform"] = "Platform must be ios, android or web"
+	}
+
+	return errors
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Outbox email states. Messages move pending -> sending -> sent, or back to pending
+// with a later NextAttemptAt on failure, and to dead once retries are exhausted.
+const (
+	OutboxEmailPending = "pending"
+	OutboxEmailSending = "sending"
+	OutboxEmailSent    = "sent"
+	OutboxEmailDead    = "dead"
+)
+
+// OutboxEmail is a queued outgoing email as shown to admins. Bodies are omitted.
+type OutboxEmail struct {
+	ID            string            `json:"id"`
+	To            string            `json:"to"`
+	Subject       string            `json:"subject"`
+	Tags          map[string]string `json:"tags,omitempty"`
+	Status        string            `json:"status"`
+	Attempts      int               `json:"attempts"`
+	LastError     string            `json:"last_error,omitempty"`
+	NextAttemptAt time.Time         `json:"next_attempt_at"`
+	CreatedAt     time.Time         `json:"created_at"`
+	SentAt        *time.Time        `json:"sent_at,omitempty"`
+}
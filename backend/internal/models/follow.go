@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// Follow records that a user follows a seller to hear about their sales.
+type Follow struct {
+	ID         string    `json:"id"`
+	FollowerID string    `json:"follower_id"`
+	SellerID   string    `json:"seller_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
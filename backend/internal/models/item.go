@@ -5,14 +5,16 @@ import (
 )
 
 type Item struct {
-	ID          string    `json:"id"`
-	SaleID      string    `json:"sale_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	ImageURLs   []string  `json:"image_urls,omitempty"`
-	Category    string    `json:"category"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          string   `json:"id"`
+	SaleID      string   `json:"sale_id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Price       float64  `json:"price"`
+	ImageURLs   []string `json:"image_urls,omitempty"`
+	Category    string   `json:"category"`
+	// Hidden items were taken down by moderation; only the owner and co-hosts see them.
+	Hidden    bool      `json:"hidden,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type CreateItemRequest struct {
@@ -0,0 +1,223 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Moderation queue target types.
+const (
+	ModerationTargetImage   = "image"
+	ModerationTargetSale    = "sale"
+	ModerationTargetItem    = "item"
+	ModerationTargetProfile = "profile"
+	// Reviews and seller replies are both targeted by review ID.
+	ModerationTargetReview      = "review"
+	ModerationTargetReviewReply = "review_reply"
+)
+
+// ItemTargetID is the moderation target ID of an item: "saleID/itemID".
+func ItemTargetID(saleID, itemID string) string {
+	return saleID + "/" + itemID
+}
+
+// SplitItemTargetID splits an ItemTargetID back into its sale and item IDs.
+func SplitItemTargetID(targetID string) (saleID, itemID string, ok bool) {
+	saleID, itemID, ok = strings.Cut(targetID, "/")
+	return saleID, itemID, ok && saleID != "" && itemID != ""
+}
+
+// Moderation queue states. An entry is pending until a reviewer claims it, and
+// stays claimed until they approve or reject it.
+const (
+	ModerationQueuePending  = "pending"
+	ModerationQueueClaimed  = "claimed"
+	ModerationQueueApproved = "approved"
+	ModerationQueueRejected = "rejected"
+)
+
+// Where a queue entry came from.
+const (
+	ModerationSourceClassifier = "classifier"
+	ModerationSourceText       = "text"
+	ModerationSourceReport     = "report"
+)
+
+// ModerationClaimTTL is how long a claim keeps other reviewers off an entry.
+const ModerationClaimTTL = 30 * time.Minute
+
+// ModerationQueueItem is content waiting for a human decision. TargetID is the
+// pending/ object path for images, the sale ID for sales, an ItemTargetID for items,
+// the user ID for profiles and the review ID for reviews and replies; UserID is
+// always the content owner. DecisionID is the moderation run that recorded the
+// reviewer's decision.
+type ModerationQueueItem struct {
+	ID         string `json:"id"`
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	// UploadType is set for images (sale_cover, sale_item, profile_photo, curb_alert).
+	UploadType string `json:"upload_type,omitempty"`
+	UserID     string `json:"user_id"`
+	Source     string `json:"source"`
+	// Rule is the policy or text rule that sent the content to review, or for user
+	// reports the most common reason.
+	Rule           string     `json:"rule,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+	Status         string     `json:"status"`
+	ClaimedBy      string     `json:"claimed_by,omitempty"`
+	ClaimedAt      *time.Time `json:"claimed_at,omitempty"`
+	ReviewerID     string     `json:"reviewer_id,omitempty"`
+	DecisionReason string     `json:"decision_reason,omitempty"`
+	DecisionID     string     `json:"decision_id,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Open reports whether the entry still needs a decision.
+func (q *ModerationQueueItem) Open() bool {
+	return q.Status == ModerationQueuePending || q.Status == ModerationQueueClaimed
+}
+
+func IsModerationTarget(t string) bool {
+	switch t {
+	case ModerationTargetImage, ModerationTargetSale, ModerationTargetItem, ModerationTargetProfile,
+		ModerationTargetReview, ModerationTargetReviewReply:
+		return true
+	}
+	return false
+}
+
+func IsModerationQueueStatus(s string) bool {
+	switch s {
+	case ModerationQueuePending, ModerationQueueClaimed, ModerationQueueApproved, ModerationQueueRejected:
+		return true
+	}
+	return false
+}
+
+// ModerationDecisionRequest is an admin's approve or reject of a queue entry.
+type ModerationDecisionRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r *ModerationDecisionRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" {
+		errors["reason"] = "Reason is required"
+	} else if len(r.Reason) > 1000 {
+		errors["reason"] = "Reason must be 1000 characters or less"
+	}
+	return errors
+}
+
+// Moderation run sources: the inline check on API uploads, the Eventarc worker, a
+// human reviewer's queue decision, or text moderation of titles, descriptions and
+// bios.
+const (
+	ModerationRunUpload = "upload"
+	ModerationRunWorker = "worker"
+	ModerationRunReview = "review"
+	ModerationRunText   = "text"
+)
+
+// ModerationRunError is the outcome of a run whose classifier call failed. Image runs
+// otherwise end in "approve", "review" or "reject"; text runs in "mask", "review" or
+// "reject".
+const ModerationRunError = "error"
+
+// ModerationRun is one stored moderation decision. Its ID is the decision ID quoted
+// to users (notifications, appeals). TargetID is the pending/ object path for
+// images, the sale ID for sales, an ItemTargetID for items and the user ID for
+// profiles; it is empty for text rejected before the sale was created.
+type ModerationRun struct {
+	ID         string `json:"id"`
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	UploadType string `json:"upload_type,omitempty"`
+	UserID     string `json:"user_id"`
+	Source     string `json:"source"`
+	// Scores are the classifier's SafeSearch likelihoods by category, or for text the
+	// number of matches by "field:category".
+	Scores        map[string]string `json:"scores,omitempty"`
+	PolicyVersion string            `json:"policy_version,omitempty"`
+	Outcome       string            `json:"outcome"`
+	Rule          string            `json:"rule,omitempty"`
+	Error         string            `json:"error,omitempty"`
+	// ReviewerID and Reason are set on review decisions; QueueItemID links them to
+	// the queue entry.
+	ReviewerID  string    `json:"reviewer_id,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	QueueItemID string    `json:"queue_item_id,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// ModerationRunQuery filters the admin history API. Zero values match anything.
+type ModerationRunQuery struct {
+	UserID     string
+	TargetID   string
+	UploadType string
+	Source     string
+	Outcome    string
+	Rule       string
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
+
+// Moderation appeal states.
+const (
+	ModerationAppealPending    = "pending"
+	ModerationAppealUpheld     = "upheld"
+	ModerationAppealOverturned = "overturned"
+)
+
+// ModerationAppeal is a user's challenge to a rejection. DecisionID is the moderation
+// run being appealed; target fields are copied from it. ReviewerID and Resolution are
+// set once an admin upholds or overturns it.
+type ModerationAppeal struct {
+	ID         string     `json:"id"`
+	DecisionID string     `json:"decision_id"`
+	UserID     string     `json:"user_id"`
+	TargetType string     `json:"target_type"`
+	TargetID   string     `json:"target_id"`
+	UploadType string     `json:"upload_type,omitempty"`
+	Message    string     `json:"message"`
+	Status     string     `json:"status"`
+	ReviewerID string     `json:"reviewer_id,omitempty"`
+	Resolution string     `json:"resolution,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func IsModerationAppealStatus(s string) bool {
+	switch s {
+	case ModerationAppealPending, ModerationAppealUpheld, ModerationAppealOverturned:
+		return true
+	}
+	return false
+}
+
+// CreateModerationAppealRequest appeals one moderation decision.
+type CreateModerationAppealRequest struct {
+	DecisionID string `json:"decision_id"`
+	Message    string `json:"message"`
+}
+
+func (r *CreateModerationAppealRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+	r.DecisionID = strings.TrimSpace(r.DecisionID)
+	r.Message = strings.TrimSpace(r.Message)
+	if r.DecisionID == "" {
+		errors["decision_id"] = "Decision ID is required"
+	}
+	if r.Message == "" {
+		errors["message"] = "Message is required"
+	} else if len(r.Message) > 2000 {
+		errors["message"] = "Message must be 2000 characters or less"
+	}
+	return errors
+}
@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+// Notification types emitted by the backend.
+const (
+	NotificationFollowedSaleCreated = "followed_sale_created"
+	NotificationFollowedSaleStarted = "followed_sale_started"
+	NotificationSavedSearchMatch    = "saved_search_match"
+	NotificationFavoriteStarted     = "favorite_sale_started"
+	NotificationFavoriteRescheduled = "favorite_sale_rescheduled"
+	NotificationFavoriteCancelled   = "favorite_sale_cancelled"
+	NotificationImageRejected       = "moderation_image_rejected"
+	NotificationContentHidden       = "moderation_content_hidden"
+	NotificationWantedMatchBuyer    = "wanted_match_buyer"
+	NotificationWantedMatchSeller   = "wanted_match_seller"
+	NotificationEventJoinRequested  = "event_join_requested"
+	NotificationEventJoinDecided    = "event_join_decided"
+	NotificationCoHostInvited       = "cohost_invited"
+	NotificationReportReceived      = "report_received"
+
+	// NotificationDigest summarises several held notifications in a single push.
+	NotificationDigest = "digest"
+)
+
+// NotificationTypes lists the types users can configure in their preferences.
+var NotificationTypes = []string{
+	NotificationFollowedSaleCreated,
+	NotificationFollowedSaleStarted,
+	NotificationSavedSearchMatch,
+	NotificationFavoriteStarted,
+	NotificationFavoriteRescheduled,
+	NotificationFavoriteCancelled,
+	NotificationImageRejected,
+	NotificationContentHidden,
+	NotificationWantedMatchBuyer,
+	NotificationWantedMatchSeller,
+	NotificationEventJoinRequested,
+	NotificationEventJoinDecided,
+	NotificationCoHostInvited,
+	NotificationReportReceived,
+}
+
+// IsNotificationType reports whether t is a known, configurable notification type.
+func IsNotificationType(t string) bool {
+	for _, known := range NotificationTypes {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLowPriorityNotification reports whether a notification type can wait for a digest.
+// Low-priority notices are informational; anything time-sensitive (a sale starting or
+// being cancelled, a moderation decision) is always delivered immediately.
+func IsLowPriorityNotification(t string) bool {
+	switch t {
+	case NotificationFollowedSaleCreated, NotificationSavedSearchMatch, NotificationFavoriteRescheduled,
+		NotificationWantedMatchSeller, NotificationReportReceived:
+		return true
+	}
+	return false
+}
+
+// Notification is a user-facing message generated by the backend in response to an event.
+type Notification struct {
+	ID        string            `json:"id"`
+	UserID    string            `json:"user_id"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data,omitempty"`
+	Read      bool              `json:"read"`
+	ReadAt    *time.Time        `json:"read_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// NotificationPage is one page of a user's inbox, newest first. Pass NextCursor as
+// the "before" query parameter to fetch the next page; it is empty on the last page.
+type NotificationPage struct {
+	Notifications []*Notification `json:"notifications"`
+	UnreadCount   int             `json:"unread_count"`
+	NextCursor    string          `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"time"
+)
+
+// NotificationChannels selects where one notification type is delivered.
+type NotificationChannels struct {
+	Push  bool `json:"push"`
+	Email bool `json:"email"`
+	InApp bool `json:"in_app"`
+}
+
+// DefaultNotificationChannels applies to any type the user has not configured.
+var DefaultNotificationChannels = NotificationChannels{Push: true, Email: false, InApp: true}
+
+// QuietHours suppresses pushes between Start and End ("HH:MM", 24h) in the user's time
+// zone. End may be earlier than Start to span midnight. Held pushes are delivered once
+// quiet hours end; the in-app inbox is never held.
+type QuietHours struct {
+	Enabled bool   `json:"enabled"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+// NotificationPreferences is a user's delivery settings. Users without a stored document
+// get DefaultNotificationPreferences.
+type NotificationPreferences struct {
+	UserID     string                          `json:"user_id"`
+	TimeZone   string                          `json:"time_zone"`
+	Types      map[string]NotificationChannels `json:"types"`
+	QuietHours QuietHours                      `json:"quiet_hours"`
+	// Digest batches low-priority pushes into one summary sent daily at DigestHour
+	// (0-23, user's time zone).
+	Digest     bool      `json:"digest"`
+	DigestHour int       `json:"digest_hour"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func DefaultNotificationPreferences(userID string) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:     userID,
+		TimeZone:   "UTC",
+		Types:      map[string]NotificationChannels{},
+		QuietHours: QuietHours{Enabled: false, Start: "22:00", End: "07:00"},
+		Digest:     false,
+		DigestHour: 18,
+	}
+}
+
+// ChannelsFor returns the channels enabled for a notification type.
+func (p *NotificationPreferences) ChannelsFor(notificationType string) NotificationChannels {
+	if p != nil {
+		if ch, ok := p.Types[notificationType]; ok {
+			return ch
+		}
+	}
+	return DefaultNotificationChannels
+}
+
+// Location returns the user's time zone, falling back to UTC if it cannot be loaded.
+func (p *NotificationPreferences) Location() *time.Location {
+	if p != nil && p.TimeZone != "" {
+		if loc, err := time.LoadLocation(p.TimeZone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// UpdateNotificationPreferencesRequest is a partial update: nil fields are left
+// unchanged, and Types entries are merged into the stored map.
+type UpdateNotificationPreferencesRequest struct {
+	TimeZone   *string                         `json:"time_zone"`
+	Types      map[string]NotificationChannels `json:"types"`
+	QuietHours *QuietHours                     `json:"quiet_hours"`
+	Digest     *bool                           `json:"digest"`
+	DigestHour *int                            `json:"digest_hour"`
+}
+
+func (r *UpdateNotificationPreferencesRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	if r.TimeZone != nil {
+		if _, err := time.LoadLocation(*r.TimeZone); err != nil || *r.TimeZone == "" {
+			errors["time_zone"] = "Time zone must be an IANA name such as America/Chicago"
+		}
+	}
+	for t := range r.Types {
+		if !IsNotificationType(t) {
+			errors["types"] = "Unknown notification type: " + t
+			break
+		}
+	}
+	if r.QuietHours != nil {
+		if _, ok := ParseClock(r.QuietHours.Start); !ok {
+			errors["quiet_hours.start"] = "Start must be HH:MM"
+		}
+		if _, ok := ParseClock(r.QuietHours.End); !ok {
+			errors["quiet_hours.end"] = "End must be HH:MM"
+		}
+	}
+	if r.DigestHour != nil && (*r.DigestHour < 0 || *r.DigestHour > 23) {
+		errors["digest_hour"] = "Digest hour must be between 0 and 23"
+	}
+
+	return errors
+}
+
+// ParseClock parses "HH:MM" (24h) into minutes after midnight.
+func ParseClock(s string) (int, bool) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
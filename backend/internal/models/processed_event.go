@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Processed event states. A delivery holds an event while processing until its lease
+// runs out; done events are answered without being handled again.
+const (
+	ProcessedEventProcessing = "processing"
+	ProcessedEventDone       = "done"
+)
+
+// ProcessedEvent tracks one storage finalize event through the moderation worker.
+// Key is bucket, object name and generation, so a re-upload to the same path is a
+// new event. Once the image is classified, DecisionID, Outcome and Rule hold the
+// decision that retries must carry out instead of classifying again. LeaseID
+// identifies the delivery holding the current claim.
+type ProcessedEvent struct {
+	Key         string     `json:"key"`
+	Bucket      string     `json:"bucket"`
+	Name        string     `json:"name"`
+	Generation  string     `json:"generation"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	DecisionID  string     `json:"decision_id,omitempty"`
+	Outcome     string     `json:"outcome,omitempty"`
+	Rule        string     `json:"rule,omitempty"`
+	LeaseID     string     `json:"lease_id,omitempty"`
+	LeaseUntil  time.Time  `json:"lease_until"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Decided reports whether a decision was saved by an earlier delivery.
+func (e *ProcessedEvent) Decided() bool {
+	return e.DecisionID != "" && e.Outcome != ""
+}
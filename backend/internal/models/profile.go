@@ -10,7 +10,9 @@ type Profile struct {
 	Bio         string    `json:"bio" bson:"bio,omitempty"`
 	DOB         time.Time `json:"dob" bson:"dob"`
 	PhotoURL    string    `json:"photo_url" bson:"photo_url,omitempty"`
-	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+	// Hidden profiles were taken down by moderation; other users get a 404.
+	Hidden    bool      `json:"hidden,omitempty" bson:"hidden,omitempty"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
 }
 
 // PublicProfile is safe to share with other authenticated users (no DOB).
@@ -19,6 +21,10 @@ type PublicProfile struct {
 	Email       string `json:"email"`
 	DisplayName string `json:"display_name"`
 	PhotoURL    string `json:"photo_url"`
+	// Rating aggregates reviews left on this user's sales.
+	Rating         SellerRating `json:"rating"`
+	FollowerCount  int          `json:"follower_count"`
+	FollowingCount int          `json:"following_count"`
 }
 
 type UpsertProfileRequest struct {
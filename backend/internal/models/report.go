@@ -0,0 +1,91 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Report reason codes.
+const (
+	ReportReasonScam       = "scam"
+	ReportReasonProhibited = "prohibited_item"
+	ReportReasonOffensive  = "offensive"
+	ReportReasonSpam       = "spam"
+	ReportReasonOther      = "other"
+)
+
+var ReportReasons = []string{
+	ReportReasonScam,
+	ReportReasonProhibited,
+	ReportReasonOffensive,
+	ReportReasonSpam,
+	ReportReasonOther,
+}
+
+func IsReportReason(r string) bool {
+	for _, known := range ReportReasons {
+		if known == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Report is a user's report of a sale, item or profile. TargetID follows the
+// moderation queue convention (an ItemTargetID for items); OwnerID is the user
+// whose content was reported.
+type Report struct {
+	ID         string    `json:"id"`
+	ReporterID string    `json:"reporter_id"`
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	OwnerID    string    `json:"owner_id"`
+	Reason     string    `json:"reason"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CreateReportRequest reports a sale, item or profile. Items are identified by
+// their sale and item IDs; TargetID is the sale ID, item ID or user ID.
+type CreateReportRequest struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	SaleID     string `json:"sale_id,omitempty"`
+	Reason     string `json:"reason"`
+	Note       string `json:"note"`
+}
+
+func (r *CreateReportRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+	r.TargetID = strings.TrimSpace(r.TargetID)
+	r.SaleID = strings.TrimSpace(r.SaleID)
+	r.Note = strings.TrimSpace(r.Note)
+
+	switch r.TargetType {
+	case ModerationTargetSale, ModerationTargetProfile:
+	case ModerationTargetItem:
+		if r.SaleID == "" {
+			errors["sale_id"] = "Sale ID is required when reporting an item"
+		}
+	default:
+		errors["target_type"] = "Target type must be sale, item or profile"
+	}
+	if r.TargetID == "" {
+		errors["target_id"] = "Target ID is required"
+	}
+	if !IsReportReason(r.Reason) {
+		errors["reason"] = "Reason must be one of: " + strings.Join(ReportReasons, ", ")
+	}
+	if r.Reason == ReportReasonOther && r.Note == "" {
+		errors["note"] = "Please tell us what's wrong"
+	} else if len(r.Note) > 1000 {
+		errors["note"] = "Note must be 1000 characters or less"
+	}
+	return errors
+}
+
+// ReportReceipt acknowledges a report to the reporter.
+type ReportReceipt struct {
+	Report  *Report `json:"report"`
+	Message string  `json:"message"`
+}
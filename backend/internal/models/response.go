@@ -5,7 +5,9 @@ type APIResponse struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
-	Errors  interface{} `json:"errors,omitempty"`
+	// Code is a machine-readable reason for some errors (e.g. "uploads_blocked").
+	Code   string      `json:"code,omitempty"`
+	Errors interface{} `json:"errors,omitempty"`
 }
 
 // NewSuccessResponse creates a success response
@@ -24,6 +26,15 @@ func NewErrorResponse(message string) APIResponse {
 	}
 }
 
+// NewCodedErrorResponse creates an error response with a machine-readable code
+func NewCodedErrorResponse(code, message string) APIResponse {
+	return APIResponse{
+		Success: false,
+		Error:   message,
+		Code:    code,
+	}
+}
+
 // NewValidationErrorResponse creates a validation error response
 func NewValidationErrorResponse(errors map[string]string) APIResponse {
 	return APIResponse{
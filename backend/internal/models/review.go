@@ -0,0 +1,71 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Review is a buyer's rating of a sale. Each user may review a given sale once.
+type Review struct {
+	ID        string       `json:"id"`
+	SaleID    string       `json:"sale_id"`
+	SellerID  string       `json:"seller_id"`
+	UserID    string       `json:"user_id"`
+	Rating    int          `json:"rating"`
+	Comment   string       `json:"comment"`
+	Reply     *ReviewReply `json:"reply,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	// Hidden reviews are held or taken down by moderation and left out of listings
+	// and ratings.
+	Hidden bool `json:"hidden,omitempty"`
+}
+
+// ReviewReply is the seller's public response to a review.
+type ReviewReply struct {
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	// Hidden replies are held or taken down by moderation; the review stays listed
+	// without them.
+	Hidden bool `json:"hidden,omitempty"`
+}
+
+// SellerRating is the aggregate of all reviews left on a seller's sales.
+type SellerRating struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+type CreateReviewRequest struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment"`
+}
+
+func (r *CreateReviewRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	if r.Rating < 1 || r.Rating > 5 {
+		errors["rating"] = "Rating must be between 1 and 5"
+	}
+	if len(strings.TrimSpace(r.Comment)) > 2000 {
+		errors["comment"] = "Comment is too long"
+	}
+
+	return errors
+}
+
+type ReplyReviewRequest struct {
+	Body string `json:"body"`
+}
+
+func (r *ReplyReviewRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	body := strings.TrimSpace(r.Body)
+	if body == "" {
+		errors["body"] = "Reply is required"
+	} else if len(body) > 2000 {
+		errors["body"] = "Reply is too long"
+	}
+
+	return errors
+}
@@ -5,19 +5,121 @@ import (
 )
 
 type GarageSale struct {
-	ID             string    `json:"id"`
-	UserID         string    `json:"user_id"`
-	Title          string    `json:"title"`
-	Description    string    `json:"description"`
-	Address        string    `json:"address"`
-	SaleCoverPhoto string    `json:"sale_cover_photo,omitempty"`
-	Latitude       float64   `json:"latitude"`
-	Longitude      float64   `json:"longitude"`
-	StartDate      time.Time `json:"start_date"`
-	EndDate        time.Time `json:"end_date"`
-	IsActive       bool      `json:"is_active"`
-	Items          []Item    `json:"items,omitempty"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID             string       `json:"id"`
+	UserID         string       `json:"user_id"`
+	Title          string       `json:"title"`
+	Description    string       `json:"description"`
+	Address        string       `json:"address"`
+	SaleCoverPhoto string       `json:"sale_cover_photo,omitempty"`
+	Latitude       float64      `json:"latitude"`
+	Longitude      float64      `json:"longitude"`
+	StartDate      time.Time    `json:"start_date"`
+	EndDate        time.Time    `json:"end_date"`
+	IsActive       bool         `json:"is_active"`
+	Items          []Item       `json:"items,omitempty"`
+	CoHosts        []SaleCoHost `json:"co_hosts,omitempty"`
+	// Hidden sales are held or taken down by moderation. They are kept out of
+	// discovery (map, search, feeds) and only the owner and co-hosts can open them.
+	Hidden    bool      `json:"hidden,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Co-host roles. Editors can change the sale and its items and run it; cashiers can
+// only start and end it. Deleting the sale and managing co-hosts stay with the owner.
+const (
+	CoHostRoleEditor  = "editor"
+	CoHostRoleCashier = "cashier"
+)
+
+// Co-host statuses. An invited co-host has no access until they accept.
+const (
+	CoHostStatusInvited  = "invited"
+	CoHostStatusAccepted = "accepted"
+)
+
+// MaxSaleCoHosts caps how many co-hosts (invited or accepted) a sale can have.
+const MaxSaleCoHosts = 10
+
+// SaleCoHost is someone helping the owner run a sale.
+type SaleCoHost struct {
+	UserID     string     `json:"user_id"`
+	Role       string     `json:"role"`
+	Status     string     `json:"status"`
+	InvitedAt  time.Time  `json:"invited_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+}
+
+// SaleAction is something a user may try to do to a sale.
+type SaleAction int
+
+const (
+	// SaleActionEdit covers sale details, the cover photo and items.
+	SaleActionEdit SaleAction = iota
+	// SaleActionRun covers starting and ending the sale.
+	SaleActionRun
+	// SaleActionManage covers deleting the sale and managing its co-hosts.
+	SaleActionManage
+)
+
+// CoHostRolesFor lists the co-host roles allowed to perform the action; none may manage.
+func CoHostRolesFor(action SaleAction) []string {
+	switch action {
+	case SaleActionEdit:
+		return []string{CoHostRoleEditor}
+	case SaleActionRun:
+		return []string{CoHostRoleEditor, CoHostRoleCashier}
+	default:
+		return nil
+	}
+}
+
+// CanUser reports whether userID may perform the action: the owner always can, and an
+// accepted co-host can if their role allows it.
+func (s *GarageSale) CanUser(userID string, action SaleAction) bool {
+	if userID == "" {
+		return false
+	}
+	if s.UserID == userID {
+		return true
+	}
+	for _, c := range s.CoHosts {
+		if c.UserID != userID || c.Status != CoHostStatusAccepted {
+			continue
+		}
+		for _, role := range CoHostRolesFor(action) {
+			if c.Role == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CoHost returns the user's co-host entry, if any.
+func (s *GarageSale) CoHost(userID string) *SaleCoHost {
+	for i := range s.CoHosts {
+		if s.CoHosts[i].UserID == userID {
+			return &s.CoHosts[i]
+		}
+	}
+	return nil
+}
+
+// InviteCoHostRequest invites another user to help run a sale.
+type InviteCoHostRequest struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+func (r *InviteCoHostRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+	if r.UserID == "" {
+		errors["user_id"] = "User is required"
+	}
+	if r.Role != CoHostRoleEditor && r.Role != CoHostRoleCashier {
+		errors["role"] = "Role must be editor or cashier"
+	}
+	return errors
 }
 
 type CreateSaleRequest struct {
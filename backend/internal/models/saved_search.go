@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SavedSearch is a search a user wants to be alerted about when new sales or items match.
+type SavedSearch struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	Query      string    `json:"query"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	RadiusMi   float64   `json:"radius"`
+	Categories []string  `json:"categories,omitempty"`
+	MinPrice   *float64  `json:"min_price,omitempty"`
+	MaxPrice   *float64  `json:"max_price,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SavedSearchAlert records that a saved search matched a sale. A user is alerted at most
+// once per sale, however many of their searches or items match it.
+type SavedSearchAlert struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	SearchID  string    `json:"search_id"`
+	SaleID    string    `json:"sale_id"`
+	ItemID    string    `json:"item_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateSavedSearchRequest struct {
+	Query      string   `json:"query"`
+	Latitude   float64  `json:"latitude"`
+	Longitude  float64  `json:"longitude"`
+	RadiusMi   float64  `json:"radius"`
+	Categories []string `json:"categories"`
+	MinPrice   *float64 `json:"min_price"`
+	MaxPrice   *float64 `json:"max_price"`
+}
+
+// MaxSavedSearchRadiusMi bounds how far a saved search may reach.
+const MaxSavedSearchRadiusMi = 50
+
+func (r *CreateSavedSearchRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	if len(strings.TrimSpace(r.Query)) > 200 {
+		errors["query"] = "Query is too long"
+	}
+	if r.Latitude == 0 && r.Longitude == 0 {
+		errors["location"] = "Location coordinates are required"
+	}
+	if r.RadiusMi < 0 || r.RadiusMi > MaxSavedSearchRadiusMi {
+		errors["radius"] = "Radius must be between 0 and 50 miles"
+	}
+	if len(r.Categories) > len(ItemCategories) {
+		errors["categories"] = "Too many categories"
+	}
+	if r.MinPrice != nil && *r.MinPrice < 0 {
+		errors["min_price"] = "Price cannot be negative"
+	}
+	if r.MaxPrice != nil && *r.MaxPrice < 0 {
+		errors["max_price"] = "Price cannot be negative"
+	}
+	if r.MinPrice != nil && r.MaxPrice != nil && *r.MaxPrice < *r.MinPrice {
+		errors["max_price"] = "Max price must be at least min price"
+	}
+
+	return errors
+}
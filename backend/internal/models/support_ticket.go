@@ -0,0 +1,164 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Support ticket states: open until support replies, pending while waiting on the
+// submitter, resolved once closed.
+const (
+	SupportTicketOpen     = "open"
+	SupportTicketPending  = "pending"
+	SupportTicketResolved = "resolved"
+)
+
+// SupportTicket is a stored support request. ID is the RM-... ticket number the
+// submitter sees.
+type SupportTicket struct {
+	ID         string               `json:"id"`
+	UserID     string               `json:"user_id,omitempty"`
+	Name       string               `json:"name"`
+	Email      string               `json:"email"`
+	Subject    string               `json:"subject,omitempty"`
+	Message    string               `json:"message"`
+	Context    *SupportContext      `json:"context,omitempty"`
+	Status     string               `json:"status"`
+	Replies    []SupportTicketReply `json:"replies"`
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+	ResolvedAt *time.Time           `json:"resolved_at,omitempty"`
+}
+
+// SupportContext is what the app attaches to in-app support requests so support can
+// see the exact listing and build in question. Sale and item titles are captured at
+// submission time in case the listing changes or is deleted later.
+type SupportContext struct {
+	SaleID      string   `json:"sale_id,omitempty"`
+	SaleTitle   string   `json:"sale_title,omitempty"`
+	SellerID    string   `json:"seller_id,omitempty"`
+	ItemIDs     []string `json:"item_ids,omitempty"`
+	ItemNames   []string `json:"item_names,omitempty"`
+	AppVersion  string   `json:"app_version,omitempty"`
+	Platform    string   `json:"platform,omitempty"`
+	OSVersion   string   `json:"os_version,omitempty"`
+	DeviceModel string   `json:"device_model,omitempty"`
+}
+
+// SupportTicketReply is a message from support to the submitter.
+type SupportTicketReply struct {
+	Body      string    `json:"body"`
+	AdminID   string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SupportTicketStatus is what the public status lookup returns.
+type SupportTicketStatus struct {
+	ID         string               `json:"id"`
+	Status     string               `json:"status"`
+	Message    string               `json:"message"`
+	Replies    []SupportTicketReply `json:"replies"`
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+	ResolvedAt *time.Time           `json:"resolved_at,omitempty"`
+}
+
+func (t *SupportTicket) PublicStatus() *SupportTicketStatus {
+	return &SupportTicketStatus{
+		ID:         t.ID,
+		Status:     t.Status,
+		Message:    t.Message,
+		Replies:    t.Replies,
+		CreatedAt:  t.CreatedAt,
+		UpdatedAt:  t.UpdatedAt,
+		ResolvedAt: t.ResolvedAt,
+	}
+}
+
+func IsSupportTicketStatus(s string) bool {
+	switch s {
+	case SupportTicketOpen, SupportTicketPending, SupportTicketResolved:
+		return true
+	}
+	return false
+}
+
+// CreateInAppSupportRequest is submitted by signed-in users from the app. The user's
+// identity comes from their auth token, so no name, email or CAPTCHA is needed.
+type CreateInAppSupportRequest struct {
+	Subject     string   `json:"subject"`
+	Message     string   `json:"message"`
+	SaleID      string   `json:"sale_id"`
+	ItemIDs     []string `json:"item_ids"`
+	AppVersion  string   `json:"app_version"`
+	Platform    string   `json:"platform"`
+	OSVersion   string   `json:"os_version"`
+	DeviceModel string   `json:"device_model"`
+}
+
+func (r *CreateInAppSupportRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	if len(strings.TrimSpace(r.Subject)) > 200 {
+		errors["subject"] = "Subject is too long"
+	}
+	msg := strings.TrimSpace(r.Message)
+	if msg == "" {
+		errors["message"] = "Message is required"
+	} else if len(msg) > 4000 {
+		errors["message"] = "Message is too long"
+	}
+	if len(r.ItemIDs) > 0 && strings.TrimSpace(r.SaleID) == "" {
+		errors["item_ids"] = "Items require a sale_id"
+	} else if len(r.ItemIDs) > 20 {
+		errors["item_ids"] = "Attach at most 20 items"
+	}
+	for field, v := range map[string]string{
+		"app_version":  r.AppVersion,
+		"platform":     r.Platform,
+		"os_version":   r.OSVersion,
+		"device_model": r.DeviceModel,
+	} {
+		if len(v) > 100 {
+			errors[field] = "Value is too long"
+		}
+	}
+
+	return errors
+}
+
+type ReplySupportTicketRequest struct {
+	Body string `json:"body"`
+	// Status to move the ticket to; defaults to pending (awaiting the submitter).
+	Status string `json:"status"`
+}
+
+func (r *ReplySupportTicketRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	body := strings.TrimSpace(r.Body)
+	if body == "" {
+		errors["body"] = "Reply is required"
+	} else if len(body) > 10000 {
+		errors["body"] = "Reply is too long"
+	}
+	if r.Status != "" && !IsSupportTicketStatus(r.Status) {
+		errors["status"] = "Status must be open, pending or resolved"
+	}
+
+	return errors
+}
+
+type UpdateSupportTicketStatusRequest struct {
+	Status string `json:"status"`
+}
+
+func (r *UpdateSupportTicketStatusRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	if !IsSupportTicketStatus(r.Status) {
+		errors["status"] = "Status must be open, pending or resolved"
+	}
+
+	return errors
+}
@@ -2,11 +2,70 @@ package models
 
 import "time"
 
-// UserFlag tracks moderation outcomes for a user.
+// UserFlag tracks moderation outcomes for a user. Strikes counts the strikes in
+// StrikeLog, decayed ones included; strikes removed on appeal no longer count.
+// StrikeLog keeps them individually so they can decay and be removed.
 type UserFlag struct {
 	UserID       string    `json:"user_id" bson:"user_id"`
 	Strikes      int       `json:"strikes" bson:"strikes"`
+	StrikeLog    []Strike  `json:"strike_log,omitempty" bson:"strike_log,omitempty"`
 	LastStrikeAt time.Time `json:"last_strike_at" bson:"last_strike_at"`
 	UpdatedAt    time.Time `json:"updated_at" bson:"updated_at"`
 }
 
+// Strike is one moderation strike. DecisionID is the moderation run behind it.
+type Strike struct {
+	Reason     string    `json:"reason,omitempty" bson:"reason,omitempty"`
+	DecisionID string    `json:"decision_id,omitempty" bson:"decision_id,omitempty"`
+	At         time.Time `json:"at" bson:"at"`
+}
+
+// Restriction codes, returned in APIResponse.Code when a request is refused.
+const (
+	RestrictionUploadsBlocked   = "uploads_blocked"
+	RestrictionSalesBlocked     = "sales_blocked"
+	RestrictionAccountSuspended = "account_suspended"
+)
+
+// UserRestrictions is what a user's active (not yet decayed) strikes block.
+type UserRestrictions struct {
+	ActiveStrikes  int        `json:"active_strikes"`
+	UploadsBlocked bool       `json:"uploads_blocked"`
+	SalesBlocked   bool       `json:"sales_blocked"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+}
+
+// Blocking returns the restriction code that refuses an action guarded by code, or
+// "" if it is allowed. A suspension blocks everything.
+func (u *UserRestrictions) Blocking(code string) string {
+	if u == nil {
+		return ""
+	}
+	if u.SuspendedUntil != nil && time.Now().Before(*u.SuspendedUntil) {
+		return RestrictionAccountSuspended
+	}
+	switch {
+	case code == RestrictionUploadsBlocked && u.UploadsBlocked:
+		return RestrictionUploadsBlocked
+	case code == RestrictionSalesBlocked && u.SalesBlocked:
+		return RestrictionSalesBlocked
+	}
+	return ""
+}
+
+// RestrictionMessage explains a restriction code to the user. suspendedUntil may be
+// nil.
+func RestrictionMessage(code string, suspendedUntil *time.Time) string {
+	switch code {
+	case RestrictionUploadsBlocked:
+		return "Photo uploads are blocked because of community guideline violations"
+	case RestrictionSalesBlocked:
+		return "Creating sales is blocked because of community guideline violations"
+	case RestrictionAccountSuspended:
+		if suspendedUntil != nil {
+			return "Account suspended until " + suspendedUntil.UTC().Format(time.RFC3339)
+		}
+		return "Account suspended"
+	}
+	return ""
+}
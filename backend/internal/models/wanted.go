@@ -0,0 +1,79 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxWantedRadiusMi caps how far from the buyer a wanted post matches items.
+const MaxWantedRadiusMi = 50
+
+// WantedPost is a buyer's "looking for" request. Sellers browse them nearby, and new
+// or updated items that match notify both sides.
+type WantedPost struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Description string    `json:"description"`
+	Category    string    `json:"category,omitempty"`
+	MaxPrice    *float64  `json:"max_price,omitempty"`
+	Latitude    float64   `json:"latitude"`
+	Longitude   float64   `json:"longitude"`
+	RadiusMi    float64   `json:"radius"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WantedMatch links a wanted post to an item that satisfies it. Each item matches a
+// given post at most once.
+type WantedMatch struct {
+	ID        string    `json:"id"`
+	WantedID  string    `json:"wanted_id"`
+	BuyerID   string    `json:"buyer_id"`
+	SellerID  string    `json:"seller_id"`
+	SaleID    string    `json:"sale_id"`
+	ItemID    string    `json:"item_id"`
+	ItemName  string    `json:"item_name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateWantedRequest struct {
+	Description string   `json:"description"`
+	Category    string   `json:"category"`
+	MaxPrice    *float64 `json:"max_price"`
+	Latitude    float64  `json:"latitude"`
+	Longitude   float64  `json:"longitude"`
+	RadiusMi    float64  `json:"radius"`
+}
+
+func (r *CreateWantedRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	desc := strings.TrimSpace(r.Description)
+	if desc == "" {
+		errors["description"] = "Description is required"
+	} else if len(desc) > 500 {
+		errors["description"] = "Description is too long"
+	}
+	if r.Category != "" {
+		found := false
+		for _, c := range ItemCategories {
+			if strings.EqualFold(c, r.Category) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errors["category"] = "Unknown category"
+		}
+	}
+	if r.MaxPrice != nil && *r.MaxPrice < 0 {
+		errors["max_price"] = "Price cannot be negative"
+	}
+	if r.Latitude == 0 && r.Longitude == 0 {
+		errors["location"] = "Location coordinates are required"
+	}
+	if r.RadiusMi < 0 || r.RadiusMi > MaxWantedRadiusMi {
+		errors["radius"] = "Radius must be between 0 and 50 miles"
+	}
+
+	return errors
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrEventNotFound        = errors.New("event not found")
+	ErrEventBadInput        = errors.New("bad input")
+	ErrEventForbidden       = errors.New("only the organizer can manage this event")
+	ErrEventMembershipFound = errors.New("sale already requested to join this event")
+	ErrEventMembershipNone  = errors.New("sale is not part of this event")
+	ErrEventSaleOutside     = errors.New("sale is outside the event boundary")
+	ErrEventSaleDates       = errors.New("sale dates do not overlap the event")
+)
+
+// CommunityEventService is used by handlers; production uses Mongo-backed implementation.
+type CommunityEventService interface {
+	CreateEvent(organizerID string, req *models.CreateCommunityEventRequest) (*models.CommunityEvent, error)
+	GetEvent(eventID string) (*models.CommunityEvent, error)
+	UpdateEvent(organizerID, eventID string, req *models.CreateCommunityEventRequest) (*models.CommunityEvent, error)
+	DeleteEvent(organizerID, eventID string) error
+	// ListByBounds returns events whose boundary overlaps the bounding box.
+	ListByBounds(minLat, maxLat, minLng, maxLng float64, limit int) ([]*models.CommunityEvent, error)
+	// ListClusters summarises the events overlapping the bounding box with their
+	// approved member sales, for map clustering.
+	ListClusters(minLat, maxLat, minLng, maxLng float64, limit int) ([]*models.EventCluster, error)
+	// ListMemberSales returns the approved member sales for the event page.
+	ListMemberSales(eventID string) ([]*models.GarageSale, error)
+
+	// RequestJoin asks the organizer to add one of the seller's sales. The sale must lie
+	// inside the boundary and overlap the event dates.
+	RequestJoin(sellerID, eventID, saleID string) (*models.EventMembership, error)
+	// ListRequests returns memberships for the organizer, optionally filtered by status.
+	ListRequests(organizerID, eventID, status string) ([]*models.EventMembership, error)
+	DecideRequest(organizerID, eventID, saleID string, approve bool) (*models.EventMembership, error)
+	// RemoveSale takes a sale out of an event; the seller or the organizer may do it.
+	RemoveSale(userID, eventID, saleID string) error
+}
+
+// saleFitsEvent checks a sale against the event's boundary and dates.
+func saleFitsEvent(event *models.CommunityEvent, sale *models.GarageSale) error {
+	if !event.Contains(sale.Latitude, sale.Longitude) {
+		return ErrEventSaleOutside
+	}
+	if sale.EndDate.Before(event.StartDate) || sale.StartDate.After(event.EndDate) {
+		return ErrEventSaleDates
+	}
+	return nil
+}
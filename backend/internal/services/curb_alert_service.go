@@ -0,0 +1,30 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrCurbAlertNotFound = errors.New("curb alert not found")
+	ErrCurbAlertLimit    = errors.New("curb alert limit reached")
+	ErrCurbAlertBadInput = errors.New("bad input")
+)
+
+// maxActiveCurbAlertsPerUser caps how many live curb alerts one user can have.
+const maxActiveCurbAlertsPerUser = 20
+
+// CurbAlertService is used by handlers; production uses Mongo-backed implementation.
+// Expired alerts are never returned.
+type CurbAlertService interface {
+	CreateCurbAlert(userID string, req *models.CreateCurbAlertRequest) (*models.CurbAlert, error)
+	GetCurbAlert(alertID string) (*models.CurbAlert, error)
+	// ListByBounds returns live alerts inside a bounding box for the map. Items marked
+	// gone are left out.
+	ListByBounds(minLat, maxLat, minLng, maxLng float64, limit int) ([]*models.CurbAlert, error)
+	ListMine(userID string) ([]*models.CurbAlert, error)
+	// MarkGone lets anyone who went to pick the item up report that it has been taken.
+	MarkGone(userID, alertID string) (*models.CurbAlert, error)
+	DeleteCurbAlert(userID, alertID string) error
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrDeviceNotFound = errors.New("device not found")
+	ErrDeviceBadInput = errors.New("bad input")
+)
+
+// DeviceService stores push registration tokens keyed by Firebase UID.
+type DeviceService interface {
+	// RegisterDevice upserts the token for the user. A token moves to the newest user
+	// that registers it (e.g. after signing into a different account on the same phone).
+	RegisterDevice(userID string, req *models.RegisterDeviceRequest) (*models.DeviceToken, error)
+	UnregisterDevice(userID, token string) error
+	ListUserTokens(ctx context.Context, userID string) ([]string, error)
+	// RemoveTokens drops tokens the push provider reported as no longer valid.
+	RemoveTokens(ctx context.Context, tokens []string) error
+}
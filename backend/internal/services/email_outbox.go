@@ -0,0 +1,44 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrOutboxEmailNotFound = errors.New("outbox email not found")
+	ErrOutboxBadInput      = errors.New("bad input")
+)
+
+// Outbox retry schedule: attempt n waits outboxBaseBackoff * 2^(n-1), capped at
+// outboxMaxBackoff. After outboxMaxAttempts failures a message is dead-lettered.
+const (
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = 6 * time.Hour
+	outboxMaxAttempts = 8
+	// outboxLease is how long a sender owns a claimed message before another may retry it.
+	outboxLease = 2 * time.Minute
+	// outboxSentRetention is how long delivered messages are kept for auditing.
+	outboxSentRetention = 30 * 24 * time.Hour
+)
+
+// EmailOutboxService exposes the outbox to admins.
+type EmailOutboxService interface {
+	// ListEmails returns messages in the given status (empty means any), newest first.
+	ListEmails(status string, limit int) ([]*models.OutboxEmail, error)
+	// RetryEmail puts a dead or pending message back at the front of the queue.
+	RetryEmail(id string) (*models.OutboxEmail, error)
+}
+
+func outboxBackoff(attempts int) time.Duration {
+	d := outboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+	return d
+}
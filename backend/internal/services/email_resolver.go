@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+
+	fbauth "firebase.google.com/go/v4/auth"
+)
+
+// EmailAddressResolver looks up where to email a user.
+type EmailAddressResolver interface {
+	// EmailForUser returns the user's address and display name. The address is empty
+	// if the user has none on file.
+	EmailForUser(ctx context.Context, userID string) (email string, name string, err error)
+}
+
+// UserEmailResolver prefers the profile and falls back to the Firebase Auth record,
+// matching how public profiles are filled in.
+type UserEmailResolver struct {
+	profiles   *MongoProfileService
+	authClient *fbauth.Client
+}
+
+// NewUserEmailResolver accepts nil for either source.
+func NewUserEmailResolver(profiles *MongoProfileService, authClient *fbauth.Client) *UserEmailResolver {
+	return &UserEmailResolver{profiles: profiles, authClient: authClient}
+}
+
+func (r *UserEmailResolver) EmailForUser(ctx context.Context, userID string) (string, string, error) {
+	var email, name string
+	if r.profiles != nil {
+		if prof, err := r.profiles.GetByUserID(ctx, userID); err == nil {
+			email, name = prof.Email, prof.DisplayName
+		}
+	}
+	if r.authClient != nil && (email == "" || name == "") {
+		u, err := r.authClient.GetUser(ctx, userID)
+		if err != nil {
+			if email == "" {
+				return "", "", err
+			}
+			return email, name, nil
+		}
+		if email == "" {
+			email = u.Email
+		}
+		if name == "" {
+			name = u.DisplayName
+		}
+	}
+	return email, name, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+// SaleEventType identifies a sale change that other features may react to.
+type SaleEventType string
+
+const (
+	SaleEventCreated SaleEventType = "sale.created"
+	SaleEventStarted SaleEventType = "sale.started"
+	// SaleEventRescheduled is emitted when an update changes the sale's dates; the
+	// pre-update sale is in SaleEvent.Previous.
+	SaleEventRescheduled SaleEventType = "sale.rescheduled"
+	// SaleEventDeleted carries the sale as it was just before it was deleted.
+	SaleEventDeleted SaleEventType = "sale.deleted"
+	// SaleEventItemAdded carries the new item in SaleEvent.Item.
+	SaleEventItemAdded SaleEventType = "sale.item_added"
+	// SaleEventItemUpdated carries the item as it is after the update in SaleEvent.Item.
+	SaleEventItemUpdated SaleEventType = "sale.item_updated"
+)
+
+// SaleEvent describes a sale mutation that has already been persisted.
+type SaleEvent struct {
+	Type     SaleEventType
+	ActorID  string
+	Sale     *models.GarageSale
+	Previous *models.GarageSale
+	Item     *models.Item
+	At       time.Time
+}
+
+// SaleEventListener reacts to sale events. Listeners run in the background, after the
+// originating request may have completed.
+type SaleEventListener interface {
+	HandleSaleEvent(ctx context.Context, ev SaleEvent)
+}
+
+// SaleEventBus fans sale events out to subscribed listeners.
+type SaleEventBus struct {
+	mu        sync.RWMutex
+	listeners []SaleEventListener
+}
+
+func NewSaleEventBus() *SaleEventBus {
+	return &SaleEventBus{}
+}
+
+func (b *SaleEventBus) Subscribe(l SaleEventListener) {
+	if b == nil || l == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.listeners = append(b.listeners, l)
+}
+
+// Publish delivers the event to every listener on its own goroutine so slow listeners
+// never hold up the HTTP response. Safe to call on a nil bus.
+func (b *SaleEventBus) Publish(ev SaleEvent) {
+	if b == nil || ev.Sale == nil {
+		return
+	}
+	if ev.At.IsZero() {
+		ev.At = time.Now().UTC()
+	}
+
+	b.mu.RLock()
+	listeners := append([]SaleEventListener(nil), b.listeners...)
+	b.mu.RUnlock()
+
+	for _, l := range listeners {
+		go func(l SaleEventListener) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("[events] listener panic type=%s sale=%s: %v", ev.Type, ev.Sale.ID, r)
+				}
+			}()
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			l.HandleSaleEvent(ctx, ev)
+		}(l)
+	}
+}
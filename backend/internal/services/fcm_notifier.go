@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/messaging"
+	"google.golang.org/api/option"
+)
+
+// fcmMulticastLimit is the maximum number of tokens FCM accepts per multicast request.
+const fcmMulticastLimit = 500
+
+// FCMNotifier sends push notifications through Firebase Cloud Messaging.
+type FCMNotifier struct {
+	client *messaging.Client
+}
+
+// NewFCMNotifier creates the messaging client once at server startup. Credentials follow
+// the same rules as the Firebase Auth client: explicit JSON if given, otherwise ADC.
+func NewFCMNotifier(ctx context.Context, projectID, credentialsJSON string) (*FCMNotifier, error) {
+	var appCfg *firebase.Config
+	if projectID != "" {
+		appCfg = &firebase.Config{ProjectID: projectID}
+	}
+
+	var app *firebase.App
+	var err error
+	if credentialsJSON != "" {
+		app, err = firebase.NewApp(ctx, appCfg, option.WithCredentialsJSON([]byte(credentialsJSON)))
+	} else {
+		app, err = firebase.NewApp(ctx, appCfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fcm: firebase app: %w", err)
+	}
+
+	client, err := app.Messaging(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fcm: messaging client: %w", err)
+	}
+	return &FCMNotifier{client: client}, nil
+}
+
+func (n *FCMNotifier) Send(ctx context.Context, tokens []string, msg PushMessage) ([]string, error) {
+	invalid := make([]string, 0)
+	for start := 0; start < len(tokens); start += fcmMulticastLimit {
+		end := start + fcmMulticastLimit
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+		batch := tokens[start:end]
+
+		resp, err := n.client.SendEachForMulticast(ctx, &messaging.MulticastMessage{
+			Tokens: batch,
+			Notification: &messaging.Notification{
+				Title: msg.Title,
+				Body:  msg.Body,
+			},
+			Data: msg.Data,
+		})
+		if err != nil {
+			return invalid, fmt.Errorf("fcm: send: %w", err)
+		}
+		for i, r := range resp.Responses {
+			if r.Success || r.Error == nil {
+				continue
+			}
+			if messaging.IsRegistrationTokenNotRegistered(r.Error) || messaging.IsInvalidArgument(r.Error) {
+				invalid = append(invalid, batch[i])
+			}
+		}
+	}
+	return invalid, nil
+}
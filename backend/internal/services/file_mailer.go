@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileMailer writes each email as an .eml file instead of sending it, for local
+// development and tests. Sent messages are also kept in memory.
+type FileMailer struct {
+	Dir       string
+	FromEmail string
+	FromName  string
+
+	mu   sync.Mutex
+	sent []*EmailMessage
+}
+
+func NewFileMailer(dir, fromEmail, fromName string) *FileMailer {
+	if fromEmail == "" {
+		fromEmail = "noreply@localhost"
+	}
+	return &FileMailer{Dir: dir, FromEmail: fromEmail, FromName: fromName}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg *EmailMessage) error {
+	if err := validateEmailMessage(msg); err != nil {
+		return err
+	}
+
+	fromName := m.FromName
+	if msg.FromName != "" {
+		fromName = msg.FromName
+	}
+	body, err := buildMIMEMessage(m.FromEmail, fromName, msg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return fmt.Errorf("file mailer: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102-150405"), uuid.NewString()[:8])
+	path := filepath.Join(m.Dir, name)
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		return fmt.Errorf("file mailer: %w", err)
+	}
+	log.Printf("[mail] wrote %q to=%s -> %s", msg.Subject, msg.To, path)
+
+	m.mu.Lock()
+	cp := *msg
+	m.sent = append(m.sent, &cp)
+	m.mu.Unlock()
+	return nil
+}
+
+// Sent returns copies of the messages sent so far.
+func (m *FileMailer) Sent() []*EmailMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]*EmailMessage, len(m.sent))
+	copy(out, m.sent)
+	return out
+}
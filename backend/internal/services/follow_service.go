@@ -0,0 +1,28 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrFollowNotFound       = errors.New("follow not found")
+	ErrAlreadyFollowing     = errors.New("already following seller")
+	ErrFollowSelf           = errors.New("cannot follow yourself")
+	ErrFollowBadInput       = errors.New("bad input")
+	ErrFollowSellerNotFound = errors.New("seller not found")
+)
+
+// FollowService is used by handlers; production uses Mongo-backed implementation.
+type FollowService interface {
+	// Follow returns ErrFollowSellerNotFound when sellerID has no profile.
+	Follow(followerID, sellerID string) (*models.Follow, error)
+	Unfollow(followerID, sellerID string) error
+	// ListFollowing returns the sellers a user follows (most-recent first).
+	ListFollowing(followerID string) ([]*models.Follow, error)
+	// ListFollowingSales returns upcoming sales from followed sellers, soonest first.
+	ListFollowingSales(followerID string, limit int) ([]*models.GarageSale, error)
+	// GetFollowCounts returns how many users follow userID and how many sellers userID follows.
+	GetFollowCounts(userID string) (followers int, following int, err error)
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// HCaptchaVerifier verifies hCaptcha tokens.
+type HCaptchaVerifier struct {
+	Secret     string
+	HTTPClient *http.Client
+	Endpoint   string
+}
+
+func NewHCaptchaVerifier(secret string) *HCaptchaVerifier {
+	return &HCaptchaVerifier{
+		Secret:   secret,
+		Endpoint: "https://api.hcaptcha.com/siteverify",
+		HTTPClient: &http.Client{
+			Timeout: 8 * time.Second,
+		},
+	}
+}
+
+func (v *HCaptchaVerifier) Verify(ctx context.Context, token string, remoteIP string) (bool, string, error) {
+	if v == nil {
+		return false, "verifier_not_configured", nil
+	}
+	_, reason, err := siteVerify(ctx, v.HTTPClient, v.Endpoint, v.Secret, token, remoteIP)
+	if err != nil || reason != "" {
+		return false, reason, err
+	}
+	return true, "", nil
+}
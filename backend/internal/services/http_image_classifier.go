@@ -0,0 +1,74 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPImageClassifier calls a local model server. It POSTs
+//
+//	{"uri": "gs://bucket/name", "bucket": "...", "name": "...", "metadata": {...}}
+//
+// and expects SafeSearch-style likelihoods back:
+//
+//	{"adult": "VERY_UNLIKELY", "violence": "...", "racy": "...", "spoof": "...", "medical": "..."}
+//
+// The server fetches the image itself, so it needs read access to the bucket.
+type HTTPImageClassifier struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewHTTPImageClassifier(endpoint string) *HTTPImageClassifier {
+	return &HTTPImageClassifier{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type httpClassifyRequest struct {
+	URI      string            `json:"uri"`
+	Bucket   string            `json:"bucket"`
+	Name     string            `json:"name"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+func (c *HTTPImageClassifier) Classify(ctx context.Context, img ImageRef) (*SafeSearchResult, error) {
+	body, err := json.Marshal(httpClassifyRequest{
+		URI:      img.GCSURI(),
+		Bucket:   img.Bucket,
+		Name:     img.Name,
+		Metadata: img.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrClassifierUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%w: status %d: %s", ErrClassifierUnavailable, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var out SafeSearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("image classifier: decode response: %w", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPTextClassifier calls a text moderation model server. It POSTs
+//
+//	{"text": "..."}
+//
+// and expects the flagged spans back, as byte offsets into text:
+//
+//	{"matches": [{"category": "profanity", "start": 4, "end": 8}]}
+//
+// Categories without an outcome in the text policy are sent to review.
+type HTTPTextClassifier struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewHTTPTextClassifier(endpoint string) *HTTPTextClassifier {
+	return &HTTPTextClassifier{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type httpTextClassifyResponse struct {
+	Matches []TextMatch `json:"matches"`
+}
+
+func (c *HTTPTextClassifier) Classify(ctx context.Context, text string) ([]TextMatch, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrClassifierUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%w: status %d: %s", ErrClassifierUnavailable, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var out httpTextClassifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("text classifier: decode response: %w", err)
+	}
+	// Drop spans that don't fit the text rather than trusting the server.
+	matches := out.Matches[:0]
+	for _, m := range out.Matches {
+		if m.Start >= 0 && m.Start < m.End && m.End <= len(text) {
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+)
+
+// ErrClassifierUnavailable is returned by classifiers that cannot reach their backend.
+var ErrClassifierUnavailable = errors.New("classifier unavailable")
+
+// ImageRef identifies an uploaded image for classification. Metadata is the object's
+// custom metadata when the caller has it (the worker does; inline moderation may not).
+type ImageRef struct {
+	Bucket   string
+	Name     string
+	Metadata map[string]string
+}
+
+// GCSURI returns the gs:// URI for the object.
+func (r ImageRef) GCSURI() string {
+	return fmt.Sprintf("gs://%s/%s", r.Bucket, r.Name)
+}
+
+// ImageClassifier rates an image on the SafeSearch categories. Implementations return
+// Vision likelihood strings (VERY_UNLIKELY .. VERY_LIKELY, or UNKNOWN).
+type ImageClassifier interface {
+	Classify(ctx context.Context, img ImageRef) (*SafeSearchResult, error)
+}
+
+// ImageClassifierFunc adapts a function to ImageClassifier. It is the hook for plugging
+// in a local model without a network service.
+type ImageClassifierFunc func(ctx context.Context, img ImageRef) (*SafeSearchResult, error)
+
+func (f ImageClassifierFunc) Classify(ctx context.Context, img ImageRef) (*SafeSearchResult, error) {
+	return f(ctx, img)
+}
+
+// NewImageClassifier picks a classifier by provider name: "vision" (default), "fake",
+// or "http" (a local model server at endpoint; see HTTPImageClassifier).
+func NewImageClassifier(ctx context.Context, provider, endpoint string) (ImageClassifier, error) {
+	switch provider {
+	case "vision", "":
+		return NewVisionClassifier(ctx)
+	case "fake":
+		return NewFakeImageClassifier(), nil
+	case "http":
+		if endpoint == "" {
+			return nil, fmt.Errorf("image classifier: http provider needs an endpoint")
+		}
+		return NewHTTPImageClassifier(endpoint), nil
+	default:
+		return nil, fmt.Errorf("image classifier: unknown provider %q", provider)
+	}
+}
+
+// FakeImageClassifierMetadataKey is the object metadata key a FakeImageClassifier reads
+// scores from, e.g. "adult=VERY_LIKELY,racy=POSSIBLE".
+const FakeImageClassifierMetadataKey = "fakeModeration"
+
+// FakeImageClassifier is a deterministic classifier for tests and local development.
+// Scores come from the FakeImageClassifierMetadataKey metadata entry when present,
+// otherwise from markers in the file name:
+//
+//	"unsafe" or "adult" -> adult VERY_LIKELY
+//	"violence"          -> violence LIKELY
+//	"racy"              -> racy LIKELY
+//	"spoof"             -> spoof LIKELY
+//	"medical"           -> medical LIKELY
+//	"classifier-error"  -> ErrClassifierUnavailable
+//
+// Anything not mentioned is VERY_UNLIKELY. Every call is recorded for inspection.
+type FakeImageClassifier struct {
+	mu    sync.Mutex
+	calls []ImageRef
+}
+
+func NewFakeImageClassifier() *FakeImageClassifier {
+	return &FakeImageClassifier{}
+}
+
+func (f *FakeImageClassifier) Classify(ctx context.Context, img ImageRef) (*SafeSearchResult, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, img)
+	f.mu.Unlock()
+
+	res := &SafeSearchResult{
+		Adult:    "VERY_UNLIKELY",
+		Violence: "VERY_UNLIKELY",
+		Racy:     "VERY_UNLIKELY",
+		Spoof:    "VERY_UNLIKELY",
+		Medical:  "VERY_UNLIKELY",
+	}
+
+	if spec := img.Metadata[FakeImageClassifierMetadataKey]; spec != "" {
+		for _, part := range strings.Split(spec, ",") {
+			k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+			if !ok {
+				continue
+			}
+			if field := res.field(strings.ToLower(k)); field != nil {
+				*field = strings.ToUpper(strings.TrimSpace(v))
+			}
+		}
+		return res, nil
+	}
+
+	name := strings.ToLower(path.Base(img.Name))
+	if strings.Contains(name, "classifier-error") {
+		return nil, ErrClassifierUnavailable
+	}
+	if strings.Contains(name, "unsafe") || strings.Contains(name, "adult") {
+		res.Adult = "VERY_LIKELY"
+	}
+	if strings.Contains(name, "violence") {
+		res.Violence = "LIKELY"
+	}
+	if strings.Contains(name, "racy") {
+		res.Racy = "LIKELY"
+	}
+	if strings.Contains(name, "spoof") {
+		res.Spoof = "LIKELY"
+	}
+	if strings.Contains(name, "medical") {
+		res.Medical = "LIKELY"
+	}
+	return res, nil
+}
+
+// Calls returns the images classified so far.
+func (f *FakeImageClassifier) Calls() []ImageRef {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]ImageRef, len(f.calls))
+	copy(out, f.calls)
+	return out
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var ErrMailerNotConfigured = errors.New("mailer not configured")
+
+// EmailMessage is a rendered email ready to hand to a Mailer. At least one of Text
+// and HTML must be set; most messages carry both.
+type EmailMessage struct {
+	To          string
+	ToName      string
+	ReplyTo     string
+	ReplyToName string
+	// FromName overrides the backend's default sender display name.
+	FromName string
+	Subject  string
+	Text     string
+	HTML     string
+	// Tags are passed to providers that support them (e.g. SendGrid custom args).
+	Tags map[string]string
+}
+
+// Mailer delivers emails. Implementations: SendGridMailer, SMTPMailer and FileMailer.
+type Mailer interface {
+	Send(ctx context.Context, msg *EmailMessage) error
+}
+
+// Template data for the emails the backend sends.
+type SupportRequestEmail struct {
+	Ticket  string
+	Name    string
+	Email   string
+	UserID  string
+	Subject string
+	Message string
+	Context *models.SupportContext
+}
+
+// SupportConfirmationEmail carries the ticket's lookup secret. StatusURL, if set,
+// already embeds the ticket and secret.
+type SupportConfirmationEmail struct {
+	Ticket    string
+	Name      string
+	Secret    string
+	StatusURL string
+}
+
+type SupportReplyEmail struct {
+	Ticket string
+	Name   string
+	Reply  string
+	Status string
+}
+
+type AccountDeletedEmail struct {
+	Name string
+}
+
+// ModerationAppealEmail tells a user how their appeal was resolved. Resolution is
+// the reviewer's note.
+type ModerationAppealEmail struct {
+	Name       string
+	DecisionID string
+	Overturned bool
+	Resolution string
+}
+
+//go:embed templates/email/*.tmpl
+var emailTemplateFS embed.FS
+
+// Each template file defines "<name>.subject", "<name>.text" and "<name>.html". The
+// html set escapes its data; the text set (used for subject and plain body) does not.
+var (
+	emailTextTemplates = texttemplate.Must(texttemplate.ParseFS(emailTemplateFS, "templates/email/*.tmpl"))
+	emailHTMLTemplates = htmltemplate.Must(htmltemplate.ParseFS(emailTemplateFS, "templates/email/*.tmpl"))
+)
+
+// RenderEmail renders the named template into a message. The caller fills in To.
+func RenderEmail(name string, data interface{}) (*EmailMessage, error) {
+	var subject, text, html bytes.Buffer
+	if err := emailTextTemplates.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return nil, fmt.Errorf("email %s subject: %w", name, err)
+	}
+	if err := emailTextTemplates.ExecuteTemplate(&text, name+".text", data); err != nil {
+		return nil, fmt.Errorf("email %s text: %w", name, err)
+	}
+	if err := emailHTMLTemplates.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return nil, fmt.Errorf("email %s html: %w", name, err)
+	}
+	return &EmailMessage{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// buildMIMEMessage encodes msg as an RFC 5322 message with a multipart/alternative
+// body. It is shared by the SMTP and file backends.
+func buildMIMEMessage(fromEmail, fromName string, msg *EmailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writeHeader := func(k, v string) {
+		buf.WriteString(k + ": " + v + "\r\n")
+	}
+	writeHeader("From", formatAddress(fromEmail, fromName))
+	writeHeader("To", formatAddress(msg.To, msg.ToName))
+	if msg.ReplyTo != "" {
+		writeHeader("Reply-To", formatAddress(msg.ReplyTo, msg.ReplyToName))
+	}
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	writeHeader("Date", time.Now().UTC().Format(time.RFC1123Z))
+	writeHeader("Message-ID", "<"+uuid.NewString()+"@rummage>")
+	writeHeader("MIME-Version", "1.0")
+
+	mw := multipart.NewWriter(&buf)
+	writeHeader("Content-Type", `multipart/alternative; boundary="`+mw.Boundary()+`"`)
+	buf.WriteString("\r\n")
+
+	parts := []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	}
+	for _, p := range parts {
+		if p.body == "" {
+			continue
+		}
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Type", p.contentType)
+		h.Set("Content-Transfer-Encoding", "8bit")
+		w, err := mw.CreatePart(h)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(strings.ReplaceAll(p.body, "\n", "\r\n"))); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func formatAddress(email, name string) string {
+	if name == "" {
+		return "<" + email + ">"
+	}
+	return mime.QEncoding.Encode("utf-8", name) + " <" + email + ">"
+}
+
+func validateEmailMessage(msg *EmailMessage) error {
+	if msg == nil || strings.TrimSpace(msg.To) == "" {
+		return fmt.Errorf("email: missing recipient")
+	}
+	if msg.Text == "" && msg.HTML == "" {
+		return fmt.Errorf("email: empty body")
+	}
+	return nil
+}
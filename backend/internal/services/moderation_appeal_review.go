@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+// AppealReviewer files users' appeals against moderation decisions and carries out
+// admins' rulings on them. Overturning removes the decision's strike and restores
+// hidden sales, items, profiles, reviews and replies (deleted images can't come
+// back). Either way the user is emailed the outcome.
+type AppealReviewer struct {
+	appeals   ModerationAppealService
+	runs      ModerationRunService
+	strikes   *StrikeEnforcer
+	sales     SalesService
+	profiles  *MongoProfileService
+	reviews   ReviewService
+	mailer    Mailer
+	addresses EmailAddressResolver
+	supportTo string
+}
+
+// NewAppealReviewer wires the reviewer. mailer and addresses may be nil to skip the
+// outcome email; supportTo is where replies to it go.
+func NewAppealReviewer(appeals ModerationAppealService, runs ModerationRunService, strikes *StrikeEnforcer, sales SalesService, profiles *MongoProfileService, reviews ReviewService, mailer Mailer, addresses EmailAddressResolver, supportTo string) *AppealReviewer {
+	return &AppealReviewer{
+		appeals:   appeals,
+		runs:      runs,
+		strikes:   strikes,
+		sales:     sales,
+		profiles:  profiles,
+		reviews:   reviews,
+		mailer:    mailer,
+		addresses: addresses,
+		supportTo: supportTo,
+	}
+}
+
+// File appeals one of the user's rejections. Decisions about someone else's content
+// look missing (ErrModerationRunNotFound); decisions that weren't rejections return
+// ErrDecisionNotAppealable.
+func (a *AppealReviewer) File(userID string, req *models.CreateModerationAppealRequest) (*models.ModerationAppeal, error) {
+	run, err := a.runs.Get(req.DecisionID)
+	if err != nil {
+		return nil, err
+	}
+	if run.UserID != userID {
+		return nil, ErrModerationRunNotFound
+	}
+	if run.Outcome != ModerationReject {
+		return nil, ErrDecisionNotAppealable
+	}
+
+	appeal, err := a.appeals.Create(&models.ModerationAppeal{
+		DecisionID: run.ID,
+		UserID:     userID,
+		TargetType: run.TargetType,
+		TargetID:   run.TargetID,
+		UploadType: run.UploadType,
+		Message:    req.Message,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[appeals] appeal %s filed by %s against decision %s", appeal.ID, userID, run.ID)
+	return appeal, nil
+}
+
+// Resolve upholds or overturns a pending appeal (status is
+// models.ModerationAppealUpheld or Overturned). The ruling is stored first so only
+// one admin's decision takes effect; failures undoing the decision are logged.
+func (a *AppealReviewer) Resolve(ctx context.Context, appealID, reviewerID, status, resolution string) (*models.ModerationAppeal, error) {
+	appeal, err := a.appeals.Resolve(appealID, reviewerID, status, resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	if appeal.Status == models.ModerationAppealOverturned {
+		if err := a.strikes.RemoveStrike(ctx, appeal.UserID, appeal.DecisionID); err != nil {
+			log.Printf("[appeals] remove strike failed appeal=%s user=%s err=%v", appeal.ID, appeal.UserID, err)
+		}
+		a.restore(ctx, appeal)
+	}
+
+	log.Printf("[appeals] appeal %s (decision %s) %s by %s", appeal.ID, appeal.DecisionID, appeal.Status, reviewerID)
+	a.sendOutcome(ctx, appeal)
+	return appeal, nil
+}
+
+func (a *AppealReviewer) restore(ctx context.Context, appeal *models.ModerationAppeal) {
+	var err error
+	switch appeal.TargetType {
+	case models.ModerationTargetSale:
+		if err = a.sales.SetHidden(appeal.TargetID, false); err == ErrSaleNotFound {
+			err = nil
+		}
+	case models.ModerationTargetItem:
+		saleID, itemID, _ := models.SplitItemTargetID(appeal.TargetID)
+		if err = a.sales.SetItemHidden(saleID, itemID, false); err == ErrItemNotFound {
+			err = nil
+		}
+	case models.ModerationTargetProfile:
+		err = a.profiles.SetHidden(ctx, appeal.TargetID, false)
+	case models.ModerationTargetReview:
+		if err = a.reviews.SetHidden(appeal.TargetID, false); err == ErrReviewNotFound {
+			err = nil
+		}
+	case models.ModerationTargetReviewReply:
+		if err = a.reviews.SetReplyHidden(appeal.TargetID, false); err == ErrReviewNotFound {
+			err = nil
+		}
+	}
+	if err != nil {
+		log.Printf("[appeals] restore %s %s failed: %v", appeal.TargetType, appeal.TargetID, err)
+	}
+}
+
+func (a *AppealReviewer) sendOutcome(ctx context.Context, appeal *models.ModerationAppeal) {
+	if a.mailer == nil || a.addresses == nil {
+		return
+	}
+	email, name, err := a.addresses.EmailForUser(ctx, appeal.UserID)
+	if err != nil || email == "" {
+		log.Printf("[appeals] appeal=%s no email for user=%s err=%v", appeal.ID, appeal.UserID, err)
+		return
+	}
+
+	msg, err := RenderEmail("moderation_appeal", ModerationAppealEmail{
+		Name:       name,
+		DecisionID: appeal.DecisionID,
+		Overturned: appeal.Status == models.ModerationAppealOverturned,
+		Resolution: appeal.Resolution,
+	})
+	if err == nil {
+		msg.To = email
+		msg.ToName = name
+		msg.ReplyTo = a.supportTo
+		msg.FromName = "Rummage Support"
+		msg.Tags = map[string]string{"appeal": appeal.ID}
+		err = a.mailer.Send(ctx, msg)
+	}
+	if err != nil {
+		log.Printf("[appeals] appeal=%s outcome mail error=%v", appeal.ID, err)
+	}
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrAppealNotFound        = errors.New("moderation appeal not found")
+	ErrAppealExists          = errors.New("decision already appealed")
+	ErrAppealResolved        = errors.New("moderation appeal already resolved")
+	ErrDecisionNotAppealable = errors.New("moderation decision can't be appealed")
+)
+
+// ModerationAppealService stores users' appeals against moderation decisions.
+type ModerationAppealService interface {
+	// Create stores a pending appeal. Each decision can be appealed once; a second
+	// appeal returns ErrAppealExists.
+	Create(appeal *models.ModerationAppeal) (*models.ModerationAppeal, error)
+	Get(appealID string) (*models.ModerationAppeal, error)
+	// ListByUser returns the user's appeals, newest first.
+	ListByUser(userID string, limit int) ([]*models.ModerationAppeal, error)
+	// List returns appeals in the given status (empty means any), oldest first so
+	// pending appeals are worked in order.
+	List(status string, limit int) ([]*models.ModerationAppeal, error)
+	// Resolve moves a pending appeal to upheld or overturned. Appeals that are no
+	// longer pending return ErrAppealResolved, so only one admin's decision sticks.
+	Resolve(appealID, reviewerID, status, resolution string) (*models.ModerationAppeal, error)
+}
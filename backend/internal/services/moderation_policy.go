@@ -0,0 +1,203 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Upload types, as set in the "type" metadata of pending/ objects. Policies are
+// chosen per upload type; unknown types use the default policy.
+const (
+	UploadTypeSaleCover    = "sale_cover"
+	UploadTypeSaleItem     = "sale_item"
+	UploadTypeProfilePhoto = "profile_photo"
+	UploadTypeCurbAlert    = "curb_alert"
+)
+
+// Moderation outcomes. Review keeps the image under pending/ for a human to decide.
+const (
+	ModerationApprove = "approve"
+	ModerationReview  = "review"
+	ModerationReject  = "reject"
+)
+
+// safeSearchCategories is the order categories are checked in, so the rule that
+// fires is stable when several match.
+var safeSearchCategories = []string{"adult", "violence", "racy", "spoof", "medical"}
+
+// likelihoodRank orders SafeSearch likelihoods. UNKNOWN (and anything
+// unrecognised) ranks lowest and never trips a threshold.
+var likelihoodRank = map[string]int{
+	"UNKNOWN":       0,
+	"VERY_UNLIKELY": 1,
+	"UNLIKELY":      2,
+	"POSSIBLE":      3,
+	"LIKELY":        4,
+	"VERY_LIKELY":   5,
+}
+
+// ModerationThreshold is the lowest likelihood that rejects, and the lowest that
+// sends the image to review. Either may be empty to disable that band.
+type ModerationThreshold struct {
+	Reject string `json:"reject,omitempty"`
+	Review string `json:"review,omitempty"`
+}
+
+// ModerationPolicy maps SafeSearch categories to thresholds. Categories that are
+// not listed are ignored.
+type ModerationPolicy map[string]ModerationThreshold
+
+// ModerationPolicies holds the default policy and per-upload-type overrides.
+// Version is stored on every moderation run so outcomes can be traced back to the
+// thresholds in force; files without one get a hash of their contents.
+type ModerationPolicies struct {
+	Version     string                      `json:"version,omitempty"`
+	Default     ModerationPolicy            `json:"default"`
+	UploadTypes map[string]ModerationPolicy `json:"upload_types,omitempty"`
+}
+
+// ModerationDecision is the outcome of evaluating a classifier result against a
+// policy. Rule names the threshold that fired, e.g. "sale_item:adult>=LIKELY";
+// it is empty when the image was approved.
+type ModerationDecision struct {
+	Outcome string `json:"outcome"`
+	Rule    string `json:"rule,omitempty"`
+}
+
+// DefaultModerationPolicies rejects LIKELY adult, violence and racy content (the
+// previous hard-coded behaviour) and sends POSSIBLE matches, and likely spoof or
+// medical images, to review. Profile photos are held to a stricter racy bar.
+func DefaultModerationPolicies() *ModerationPolicies {
+	base := ModerationPolicy{
+		"adult":    {Reject: "LIKELY", Review: "POSSIBLE"},
+		"violence": {Reject: "LIKELY", Review: "POSSIBLE"},
+		"racy":     {Reject: "LIKELY", Review: "POSSIBLE"},
+		"spoof":    {Review: "VERY_LIKELY"},
+		"medical":  {Review: "LIKELY"},
+	}
+	profile := base.clone()
+	profile["racy"] = ModerationThreshold{Reject: "POSSIBLE"}
+	return &ModerationPolicies{
+		Version: "builtin-1",
+		Default: base,
+		UploadTypes: map[string]ModerationPolicy{
+			UploadTypeProfilePhoto: profile,
+		},
+	}
+}
+
+// LoadModerationPolicies reads policies from a JSON file. An empty path returns
+// the defaults. Upload types missing from the file use its default policy.
+func LoadModerationPolicies(path string) (*ModerationPolicies, error) {
+	if path == "" {
+		return DefaultModerationPolicies(), nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("moderation policy: %w", err)
+	}
+	return ParseModerationPolicies(raw)
+}
+
+// ParseModerationPolicies decodes and validates a JSON policy document.
+func ParseModerationPolicies(raw []byte) (*ModerationPolicies, error) {
+	var p ModerationPolicies
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, fmt.Errorf("moderation policy: %w", err)
+	}
+	if len(p.Default) == 0 {
+		return nil, fmt.Errorf("moderation policy: default policy is required")
+	}
+	if err := p.Default.validate("default"); err != nil {
+		return nil, err
+	}
+	for name, policy := range p.UploadTypes {
+		if err := policy.validate(name); err != nil {
+			return nil, err
+		}
+	}
+	if p.Version == "" {
+		sum := sha256.Sum256(raw)
+		p.Version = "sha256:" + hex.EncodeToString(sum[:6])
+	}
+	return &p, nil
+}
+
+// For returns the policy for an upload type, falling back to the default.
+func (p *ModerationPolicies) For(uploadType string) ModerationPolicy {
+	if policy, ok := p.UploadTypes[uploadType]; ok {
+		return policy
+	}
+	return p.Default
+}
+
+// Evaluate applies the upload type's policy to a classifier result.
+func (p *ModerationPolicies) Evaluate(uploadType string, ss *SafeSearchResult) ModerationDecision {
+	name := uploadType
+	if _, ok := p.UploadTypes[uploadType]; !ok || name == "" {
+		name = "default"
+	}
+	return p.For(uploadType).Evaluate(name, ss)
+}
+
+// Evaluate returns reject if any category meets its reject threshold, otherwise
+// review if any meets its review threshold, otherwise approve. name prefixes
+// the fired rule.
+func (p ModerationPolicy) Evaluate(name string, ss *SafeSearchResult) ModerationDecision {
+	review := ""
+	for _, category := range safeSearchCategories {
+		t, ok := p[category]
+		if !ok {
+			continue
+		}
+		got := likelihoodRank[normalizeLikelihood(*ss.field(category))]
+		if t.Reject != "" && got >= likelihoodRank[t.Reject] {
+			return ModerationDecision{Outcome: ModerationReject, Rule: fmt.Sprintf("%s:%s>=%s", name, category, t.Reject)}
+		}
+		if review == "" && t.Review != "" && got >= likelihoodRank[t.Review] {
+			review = fmt.Sprintf("%s:%s>=%s", name, category, t.Review)
+		}
+	}
+	if review != "" {
+		return ModerationDecision{Outcome: ModerationReview, Rule: review}
+	}
+	return ModerationDecision{Outcome: ModerationApprove}
+}
+
+func (p ModerationPolicy) validate(name string) error {
+	for category, t := range p {
+		if (&SafeSearchResult{}).field(category) == nil {
+			return fmt.Errorf("moderation policy %s: unknown category %q", name, category)
+		}
+		for _, l := range []string{t.Reject, t.Review} {
+			if l == "" {
+				continue
+			}
+			if rank, ok := likelihoodRank[l]; !ok || rank == 0 {
+				return fmt.Errorf("moderation policy %s: invalid likelihood %q for %s (want one of VERY_UNLIKELY..VERY_LIKELY)", name, l, category)
+			}
+		}
+		if t.Reject != "" && t.Review != "" && likelihoodRank[t.Review] > likelihoodRank[t.Reject] {
+			return fmt.Errorf("moderation policy %s: %s review threshold %s is above reject threshold %s", name, category, t.Review, t.Reject)
+		}
+	}
+	return nil
+}
+
+func (p ModerationPolicy) clone() ModerationPolicy {
+	out := make(ModerationPolicy, len(p))
+	for k, v := range p {
+		out[k] = v
+	}
+	return out
+}
+
+// normalizeLikelihood upper-cases classifier output so "likely" and "LIKELY"
+// rank the same.
+func normalizeLikelihood(l string) string {
+	return strings.ToUpper(strings.TrimSpace(l))
+}
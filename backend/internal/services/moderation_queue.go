@@ -0,0 +1,39 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrModerationItemNotFound = errors.New("moderation queue item not found")
+	ErrModerationItemClaimed  = errors.New("moderation queue item claimed by another reviewer")
+	ErrModerationItemDecided  = errors.New("moderation queue item already decided")
+	ErrModerationBadInput     = errors.New("bad input")
+)
+
+// ModerationQueueOpen can be passed to List for entries that still need a decision
+// (pending or claimed).
+const ModerationQueueOpen = "open"
+
+// ModerationQueueService stores content waiting for a human moderation decision.
+type ModerationQueueService interface {
+	// Enqueue adds an entry. If the target already has an open entry, that entry is
+	// returned unchanged so repeated flags don't pile up.
+	Enqueue(item *models.ModerationQueueItem) (*models.ModerationQueueItem, error)
+	Get(itemID string) (*models.ModerationQueueItem, error)
+	// LastDecided returns the target's most recently decided entry, or
+	// ErrModerationItemNotFound if a reviewer has never ruled on it.
+	LastDecided(targetType, targetID string) (*models.ModerationQueueItem, error)
+	// List returns entries in the given status (ModerationQueueOpen for pending or
+	// claimed) and target type, oldest first so the queue is worked in order. Empty
+	// filters match anything.
+	List(status, targetType string, limit int) ([]*models.ModerationQueueItem, error)
+	// Claim assigns an open entry to a reviewer. Claims expire after
+	// models.ModerationClaimTTL; re-claiming your own entry refreshes it.
+	Claim(itemID, reviewerID string) (*models.ModerationQueueItem, error)
+	// Decide records the reviewer's outcome (approved or rejected), reason and the
+	// moderation run that holds the decision.
+	Decide(itemID, reviewerID, status, reason, decisionID string) (*models.ModerationQueueItem, error)
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+// ErrModerationUnavailable is returned when an image decision is made while image
+// moderation (FIREBASE_BUCKET) is not configured.
+var ErrModerationUnavailable = errors.New("image moderation is not configured")
+
+// ModerationReviewer carries out admin decisions on moderation queue entries.
+// Images go through ModerationService's promote and delete paths; sales, items,
+// profiles, reviews and replies are hidden or restored. Rejections record a strike.
+type ModerationReviewer struct {
+	queue         ModerationQueueService
+	runs          ModerationRunService
+	images        *ModerationService
+	sales         SalesService
+	profiles      *MongoProfileService
+	reviews       ReviewService
+	strikes       *StrikeEnforcer
+	notifications NotificationPublisher
+}
+
+// NewModerationReviewer wires the reviewer. images may be nil when image moderation
+// is off; image entries then fail with ErrModerationUnavailable. Every decision is
+// recorded in runs.
+func NewModerationReviewer(queue ModerationQueueService, runs ModerationRunService, images *ModerationService, sales SalesService, profiles *MongoProfileService, reviews ReviewService, strikes *StrikeEnforcer, notifications NotificationPublisher) *ModerationReviewer {
+	return &ModerationReviewer{
+		queue:         queue,
+		runs:          runs,
+		images:        images,
+		sales:         sales,
+		profiles:      profiles,
+		reviews:       reviews,
+		strikes:       strikes,
+		notifications: notifications,
+	}
+}
+
+// Decide applies status (models.ModerationQueueApproved or Rejected) to the entry
+// and records the reviewer and reason. The entry is claimed first so two reviewers
+// can't act on it at once; the effect is applied before the decision is stored so
+// a failure leaves the entry claimed for another try. The decision ID is the entry's
+// ID, which makes that retry idempotent.
+func (r *ModerationReviewer) Decide(ctx context.Context, itemID, reviewerID, status, reason string) (*models.ModerationQueueItem, error) {
+	if status != models.ModerationQueueApproved && status != models.ModerationQueueRejected {
+		return nil, ErrModerationBadInput
+	}
+
+	item, err := r.queue.Claim(itemID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	approve := status == models.ModerationQueueApproved
+	run := newModerationRun(item.TargetType, item.TargetID, item.UploadType, item.UserID, models.ModerationRunReview)
+	// The decision is keyed by the queue entry, so when storing it fails and the
+	// reviewer tries again, the strike isn't added twice and the run is replaced.
+	run.ID = item.ID
+	run.StartedAt = time.Now().UTC()
+	run.Outcome = ModerationReject
+	if approve {
+		run.Outcome = ModerationApprove
+	}
+	run.Rule = item.Rule
+	run.ReviewerID = reviewerID
+	run.Reason = reason
+	run.QueueItemID = item.ID
+
+	switch item.TargetType {
+	case models.ModerationTargetImage:
+		if r.images == nil {
+			return nil, ErrModerationUnavailable
+		}
+		if approve {
+			if _, err := r.images.ApprovePending(ctx, item.TargetID, item.UploadType); err != nil {
+				return nil, err
+			}
+		} else {
+			r.images.RejectPending(ctx, item.TargetID, item.UploadType, item.UserID, item.Rule, run.ID)
+		}
+	case models.ModerationTargetSale:
+		err := r.sales.SetHidden(item.TargetID, !approve)
+		if err == ErrSaleNotFound && !approve {
+			// Deleted since it was queued; nothing left to hide.
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	case models.ModerationTargetItem:
+		saleID, itemID, _ := models.SplitItemTargetID(item.TargetID)
+		err := r.sales.SetItemHidden(saleID, itemID, !approve)
+		if err == ErrItemNotFound && !approve {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	case models.ModerationTargetProfile:
+		if err := r.profiles.SetHidden(ctx, item.TargetID, !approve); err != nil {
+			return nil, err
+		}
+	case models.ModerationTargetReview, models.ModerationTargetReviewReply:
+		setHidden := r.reviews.SetHidden
+		if item.TargetType == models.ModerationTargetReviewReply {
+			setHidden = r.reviews.SetReplyHidden
+		}
+		err := setHidden(item.TargetID, !approve)
+		if err == ErrReviewNotFound && !approve {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !approve && item.TargetType != models.ModerationTargetImage {
+		r.strikes.AddStrike(ctx, item.UserID, reason, run.ID)
+		r.notifyHidden(ctx, item, run.ID)
+	}
+
+	run.CompletedAt = time.Now().UTC()
+	if err := r.runs.Record(run); err != nil {
+		log.Printf("[moderation] record run failed item=%s err=%v", item.ID, err)
+	}
+
+	log.Printf("[moderation] queue item %s (%s %s) %s by %s: %s", item.ID, item.TargetType, item.TargetID, status, reviewerID, reason)
+	return r.queue.Decide(itemID, reviewerID, status, reason, run.ID)
+}
+
+func (r *ModerationReviewer) notifyHidden(ctx context.Context, item *models.ModerationQueueItem, decisionID string) {
+	if r.notifications == nil || item.UserID == "" {
+		return
+	}
+	n := &models.Notification{
+		UserID: item.UserID,
+		Type:   models.NotificationContentHidden,
+		Data:   map[string]string{"target_type": item.TargetType, "target_id": item.TargetID, "decision_id": decisionID},
+	}
+	switch item.TargetType {
+	case models.ModerationTargetSale:
+		n.Title = "Sale removed"
+		n.Body = "One of your sales was removed because it violates our community guidelines."
+		n.Data["sale_id"] = item.TargetID
+	case models.ModerationTargetItem:
+		n.Title = "Item removed"
+		n.Body = "One of your items was removed because it violates our community guidelines."
+		n.Data["sale_id"], n.Data["item_id"], _ = models.SplitItemTargetID(item.TargetID)
+	case models.ModerationTargetProfile:
+		n.Title = "Profile hidden"
+		n.Body = "Your profile was hidden because it violates our community guidelines."
+	case models.ModerationTargetReview:
+		n.Title = "Review removed"
+		n.Body = "One of your reviews was removed because it violates our community guidelines."
+		n.Data["review_id"] = item.TargetID
+	case models.ModerationTargetReviewReply:
+		n.Title = "Reply removed"
+		n.Body = "One of your replies to a review was removed because it violates our community guidelines."
+		n.Data["review_id"] = item.TargetID
+	}
+	if err := r.notifications.Publish(ctx, n); err != nil {
+		log.Printf("[moderation] notify failed userID=%s err=%v", item.UserID, err)
+	}
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var ErrModerationRunNotFound = errors.New("moderation run not found")
+
+// ModerationRunService is the audit trail of moderation decisions.
+type ModerationRunService interface {
+	// Record stores a run. An empty ID is filled in; recording an ID that is already
+	// stored replaces it, so a retried decision keeps one record with its final
+	// outcome.
+	Record(run *models.ModerationRun) error
+	Get(runID string) (*models.ModerationRun, error)
+	// ListByUser returns the user's runs with the given outcome (empty means any),
+	// newest first.
+	ListByUser(userID, outcome string, limit int) ([]*models.ModerationRun, error)
+	// Query returns runs matching q, newest first.
+	Query(q models.ModerationRunQuery) ([]*models.ModerationRun, error)
+}
+
+// newModerationRun starts a run with a fresh decision ID.
+func newModerationRun(targetType, targetID, uploadType, userID, source string) *models.ModerationRun {
+	return &models.ModerationRun{
+		ID:         uuid.New().String(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		UploadType: uploadType,
+		UserID:     userID,
+		Source:     source,
+	}
+}
+
+// SafeSearchScores flattens a classifier result for storage.
+func SafeSearchScores(ss *SafeSearchResult) map[string]string {
+	if ss == nil {
+		return nil
+	}
+	scores := make(map[string]string, len(safeSearchCategories))
+	for _, category := range safeSearchCategories {
+		scores[category] = *ss.field(category)
+	}
+	return scores
+}
@@ -11,89 +11,154 @@ import (
 	"time"
 
 	"cloud.google.com/go/storage"
+
+	"github.com/rummage/backend/internal/models"
 )
 
-// ErrImageRejected is returned when SafeSearch flags an image as unsafe.
+// ErrImageRejected is returned when the classifier flags an image as unsafe.
 var ErrImageRejected = errors.New("image rejected: violates community guidelines")
 
-// ModerationResult holds the outcome of a successful moderation pass.
+// ModerationResult holds the outcome of a moderation pass that did not reject.
+// When Outcome is ModerationReview the image stays at PendingPath and
+// ApprovedURL is empty. DecisionID is the stored moderation run, if any.
 type ModerationResult struct {
 	ApprovedURL string
+	PendingPath string
+	Outcome     string
+	Rule        string
+	DecisionID  string
 }
 
-// ModerationService runs Vision SafeSearch on images in Firebase Storage and
-// promotes safe ones from pending/ to approved paths inline (synchronously).
+// URL is the reference to store: the approved download URL, or the pending
+// path while the image waits for review.
+func (r *ModerationResult) URL() string {
+	if r.Outcome == ModerationReview {
+		return r.PendingPath
+	}
+	return r.ApprovedURL
+}
+
+// ModerationService classifies images in Firebase Storage and promotes safe ones
+// from pending/ to approved paths inline (synchronously). Images in the review
+// band go to the moderation queue; ApprovePending and RejectPending carry out the
+// reviewer's decision.
 type ModerationService struct {
-	gcs     *storage.Client
-	bucket  string
-	flagSvc *MongoUserFlagService
+	gcs           *storage.Client
+	bucket        string
+	classifier    ImageClassifier
+	policies      *ModerationPolicies
+	queue         ModerationQueueService
+	runs          ModerationRunService
+	refs          *PendingImageReferences
+	strikes       *StrikeEnforcer
+	notifications NotificationPublisher
 }
 
 // NewModerationService creates a storage client once at server startup.
-// flagSvc may be nil if strike tracking is not needed.
-func NewModerationService(ctx context.Context, bucket string, flagSvc *MongoUserFlagService) (*ModerationService, error) {
+// policies may be nil to use DefaultModerationPolicies. queue may be nil to hold
+// review-band images without queueing them, and runs may be nil to skip the audit
+// trail. strikes may be nil if strike tracking is not needed; notifications may be
+// nil if uploaders should not be told about rejections.
+func NewModerationService(ctx context.Context, bucket string, classifier ImageClassifier, policies *ModerationPolicies, queue ModerationQueueService, runs ModerationRunService, refs *PendingImageReferences, strikes *StrikeEnforcer, notifications NotificationPublisher) (*ModerationService, error) {
+	if classifier == nil {
+		return nil, fmt.Errorf("moderation: classifier is required")
+	}
+	if policies == nil {
+		policies = DefaultModerationPolicies()
+	}
 	client, err := storage.NewClient(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("moderation: storage client: %w", err)
 	}
 	return &ModerationService{
-		gcs:     client,
-		bucket:  bucket,
-		flagSvc: flagSvc,
+		gcs:           client,
+		bucket:        bucket,
+		classifier:    classifier,
+		policies:      policies,
+		queue:         queue,
+		runs:          runs,
+		refs:          refs,
+		strikes:       strikes,
+		notifications: notifications,
 	}, nil
 }
 
-// ModerateAndPromote runs SafeSearch on a pending/ path. If safe, promotes
-// (copy to final path, delete pending, return download URL). If unsafe, deletes
-// the pending object, records a strike, and returns ErrImageRejected.
-func (m *ModerationService) ModerateAndPromote(ctx context.Context, pendingPath, userID string) (*ModerationResult, error) {
+// ModerateAndPromote classifies a pending/ path and applies the upload type's
+// policy. Approved images are promoted (copy to final path, delete pending,
+// return download URL). Images in the review band are left under pending/.
+// Rejected images are deleted, a strike is recorded, and ErrImageRejected is
+// returned. Users whose strikes block uploads get ErrUploadsBlocked or
+// ErrAccountSuspended.
+func (m *ModerationService) ModerateAndPromote(ctx context.Context, pendingPath, userID, uploadType string) (*ModerationResult, error) {
 	if !strings.HasPrefix(pendingPath, "pending/") {
 		// Already approved — nothing to do.
-		return &ModerationResult{ApprovedURL: pendingPath}, nil
+		return &ModerationResult{ApprovedURL: pendingPath, Outcome: ModerationApprove}, nil
+	}
+	if err := m.strikes.Allow(userID, models.RestrictionUploadsBlocked); err != nil {
+		return nil, err
 	}
 
-	gcsURI := fmt.Sprintf("gs://%s/%s", m.bucket, pendingPath)
-	log.Printf("[moderation] running SafeSearch on %s", gcsURI)
+	img := ImageRef{Bucket: m.bucket, Name: pendingPath}
+	log.Printf("[moderation] classifying %s", img.GCSURI())
 
-	ss, err := DetectSafeSearch(ctx, gcsURI)
+	run := newModerationRun(models.ModerationTargetImage, pendingPath, uploadType, userID, models.ModerationRunUpload)
+	run.PolicyVersion = m.policies.Version
+	run.StartedAt = time.Now().UTC()
+
+	ss, err := m.classifier.Classify(ctx, img)
 	if err != nil {
-		log.Printf("[moderation] SafeSearch error path=%s err=%v", pendingPath, err)
-		return nil, fmt.Errorf("moderation: safesearch: %w", err)
+		log.Printf("[moderation] classify error path=%s err=%v", pendingPath, err)
+		run.Outcome = models.ModerationRunError
+		run.Error = err.Error()
+		m.recordRun(run)
+		return nil, fmt.Errorf("moderation: classify: %w", err)
 	}
 
-	log.Printf("[moderation] SafeSearch result for %s: adult=%s violence=%s racy=%s isUnsafe=%v",
-		pendingPath, ss.Adult, ss.Violence, ss.Racy, ss.IsUnsafe())
+	decision := m.policies.Evaluate(uploadType, ss)
+	log.Printf("[moderation] classifier result for %s: adult=%s violence=%s racy=%s spoof=%s medical=%s outcome=%s rule=%s",
+		pendingPath, ss.Adult, ss.Violence, ss.Racy, ss.Spoof, ss.Medical, decision.Outcome, decision.Rule)
 
-	if ss.IsUnsafe() {
-		log.Printf("[moderation] image UNSAFE — deleting %s", pendingPath)
-		if err := m.deleteObject(ctx, pendingPath); err != nil {
-			log.Printf("[moderation] delete failed path=%s err=%v", pendingPath, err)
-		}
-		if m.flagSvc != nil && userID != "" {
-			if _, err := m.flagSvc.AddStrike(ctx, userID); err != nil {
-				log.Printf("[moderation] strike failed userID=%s err=%v", userID, err)
-			}
-		}
+	run.Scores = SafeSearchScores(ss)
+	run.Outcome = decision.Outcome
+	run.Rule = decision.Rule
+	// Recorded before acting so the decision ID exists for the notification.
+	m.recordRun(run)
+
+	switch decision.Outcome {
+	case ModerationReject:
+		log.Printf("[moderation] image REJECTED (%s) — deleting %s", decision.Rule, pendingPath)
+		m.RejectPending(ctx, pendingPath, uploadType, userID, decision.Rule, run.ID)
 		return nil, ErrImageRejected
+	case ModerationReview:
+		log.Printf("[moderation] image held for REVIEW (%s): %s", decision.Rule, pendingPath)
+		if err := m.setObjectMetadata(ctx, pendingPath, map[string]string{
+			"moderation":     ModerationReview,
+			"moderationRule": decision.Rule,
+		}); err != nil {
+			log.Printf("[moderation] mark review failed path=%s err=%v", pendingPath, err)
+		}
+		m.enqueueReview(pendingPath, userID, uploadType, decision.Rule)
+		return &ModerationResult{PendingPath: pendingPath, Outcome: ModerationReview, Rule: decision.Rule, DecisionID: run.ID}, nil
 	}
 
 	// Safe — promote.
 	finalName := strings.TrimPrefix(pendingPath, "pending/")
-	token := newToken()
-	approvedURL := firebaseDownloadURL(m.bucket, finalName, token)
 
 	log.Printf("[moderation] image SAFE — promoting %s -> %s", pendingPath, finalName)
-	if err := m.promoteObject(ctx, pendingPath, finalName, token); err != nil {
+	token, err := m.promoteObject(ctx, pendingPath, finalName, newToken())
+	if err != nil {
 		return nil, fmt.Errorf("moderation: promote: %w", err)
 	}
+	approvedURL := firebaseDownloadURL(m.bucket, finalName, token)
 
-	return &ModerationResult{ApprovedURL: approvedURL}, nil
+	return &ModerationResult{ApprovedURL: approvedURL, PendingPath: pendingPath, Outcome: ModerationApprove, DecisionID: run.ID}, nil
 }
 
 // ModerateMultiple moderates a list of image URLs. Already-approved URLs are
-// passed through. Pending URLs are moderated inline. Returns approved URLs
-// and any error (first rejection stops processing).
-func (m *ModerationService) ModerateMultiple(ctx context.Context, paths []string, userID string) ([]string, error) {
+// passed through. Pending URLs are moderated inline. Returns the URLs to store
+// (pending paths for images held for review) and any error (first rejection
+// stops processing).
+func (m *ModerationService) ModerateMultiple(ctx context.Context, paths []string, userID, uploadType string) ([]string, error) {
 	approved := make([]string, 0, len(paths))
 	for _, p := range paths {
 		if strings.TrimSpace(p) == "" {
@@ -103,19 +168,101 @@ func (m *ModerationService) ModerateMultiple(ctx context.Context, paths []string
 			approved = append(approved, p)
 			continue
 		}
-		res, err := m.ModerateAndPromote(ctx, p, userID)
+		res, err := m.ModerateAndPromote(ctx, p, userID, uploadType)
 		if err != nil {
 			return nil, err
 		}
-		approved = append(approved, res.ApprovedURL)
+		approved = append(approved, res.URL())
 	}
 	return approved, nil
 }
 
-func (m *ModerationService) promoteObject(ctx context.Context, from, to, token string) error {
+// ApprovePending promotes a held pending/ image and points the records that use it
+// at the approved download URL. It is safe to retry: an image an earlier attempt
+// already promoted keeps its download URL.
+func (m *ModerationService) ApprovePending(ctx context.Context, pendingPath, uploadType string) (string, error) {
+	if !strings.HasPrefix(pendingPath, "pending/") {
+		return "", ErrModerationBadInput
+	}
+	finalName := strings.TrimPrefix(pendingPath, "pending/")
+
+	log.Printf("[moderation] promoting held image %s -> %s", pendingPath, finalName)
+	token, err := m.promoteObject(ctx, pendingPath, finalName, newToken())
+	if err != nil {
+		return "", fmt.Errorf("moderation: promote: %w", err)
+	}
+	approvedURL := firebaseDownloadURL(m.bucket, finalName, token)
+	if m.refs != nil {
+		if err := m.refs.Approve(ctx, uploadType, pendingPath, approvedURL); err != nil {
+			return "", fmt.Errorf("moderation: update references: %w", err)
+		}
+	}
+	return approvedURL, nil
+}
+
+// RejectPending deletes a pending/ image, clears records that point at it, records
+// a strike and tells the uploader, quoting decisionID. Failures are logged: the
+// image is gone from the uploader's point of view either way.
+func (m *ModerationService) RejectPending(ctx context.Context, pendingPath, uploadType, userID, rule, decisionID string) {
+	if err := m.deleteObject(ctx, pendingPath); err != nil && err != storage.ErrObjectNotExist {
+		log.Printf("[moderation] delete failed path=%s err=%v", pendingPath, err)
+	}
+	if m.refs != nil {
+		if err := m.refs.Reject(ctx, uploadType, pendingPath); err != nil {
+			log.Printf("[moderation] clear references failed path=%s err=%v", pendingPath, err)
+		}
+	}
+	m.strikes.AddStrike(ctx, userID, rule, decisionID)
+	m.notifyRejected(ctx, userID, pendingPath, rule, decisionID)
+}
+
+func (m *ModerationService) recordRun(run *models.ModerationRun) {
+	if m.runs == nil {
+		return
+	}
+	run.CompletedAt = time.Now().UTC()
+	if err := m.runs.Record(run); err != nil {
+		log.Printf("[moderation] record run failed path=%s err=%v", run.TargetID, err)
+	}
+}
+
+func (m *ModerationService) enqueueReview(pendingPath, userID, uploadType, rule string) {
+	if m.queue == nil {
+		return
+	}
+	if _, err := m.queue.Enqueue(&models.ModerationQueueItem{
+		TargetType: models.ModerationTargetImage,
+		TargetID:   pendingPath,
+		UploadType: uploadType,
+		UserID:     userID,
+		Source:     models.ModerationSourceClassifier,
+		Rule:       rule,
+	}); err != nil {
+		log.Printf("[moderation] enqueue failed path=%s err=%v", pendingPath, err)
+	}
+}
+
+func (m *ModerationService) notifyRejected(ctx context.Context, userID, pendingPath, rule, decisionID string) {
+	if m.notifications == nil || userID == "" {
+		return
+	}
+	n := &models.Notification{
+		UserID: userID,
+		Type:   models.NotificationImageRejected,
+		Title:  "Photo removed",
+		Body:   "One of your photos was removed because it violates our community guidelines.",
+		Data:   map[string]string{"path": pendingPath, "rule": rule, "decision_id": decisionID},
+	}
+	if err := m.notifications.Publish(ctx, n); err != nil {
+		log.Printf("[moderation] notify failed userID=%s err=%v", userID, err)
+	}
+}
+
+// promoteObject promotes from to to and returns the download token the promoted
+// object ends up with (see PromoteObject).
+func (m *ModerationService) promoteObject(ctx context.Context, from, to, token string) (string, error) {
 	b := m.gcs.Bucket(m.bucket)
 	src := b.Object(from)
-	dst := b.Object(to)
 
 	// Read source metadata with retry for eventual consistency.
 	// Firebase Storage may need a moment to finalize uploads before the object is accessible.
@@ -134,23 +281,76 @@ func (m *ModerationService) promoteObject(ctx context.Context, from, to, token s
 			time.Sleep(backoff)
 			continue
 		}
-		return fmt.Errorf("source attrs: %w", err)
+		// Still missing: an earlier attempt may have promoted it already, which
+		// PromoteObject checks for.
+		if err == storage.ErrObjectNotExist {
+			break
+		}
+		return "", fmt.Errorf("source attrs: %w", err)
+	}
+
+	var md map[string]string
+	if attrs != nil {
+		md = attrs.Metadata
+	}
+	return PromoteObject(ctx, b, from, to, md, token)
+}
+
+// PromoteObject copies from to to in b with metadata, moderation=approved and the
+// Firebase download token, deletes from and returns the token. It is safe to
+// retry: if from is already gone because an earlier attempt promoted it, the token
+// already on to is returned.
+func PromoteObject(ctx context.Context, b *storage.BucketHandle, from, to string, metadata map[string]string, token string) (string, error) {
+	src := b.Object(from)
+	dst := b.Object(to)
+
+	if _, err := src.Attrs(ctx); errors.Is(err, storage.ErrObjectNotExist) {
+		attrs, dstErr := dst.Attrs(ctx)
+		if dstErr != nil {
+			return "", fmt.Errorf("pending object gone and promoted object unreadable: %w", dstErr)
+		}
+		if existing := attrs.Metadata["firebaseStorageDownloadTokens"]; existing != "" {
+			return existing, nil
+		}
+		return "", fmt.Errorf("pending object gone and promoted object has no download token")
+	} else if err != nil {
+		return "", fmt.Errorf("source attrs: %w", err)
 	}
 
 	md := map[string]string{}
-	for k, v := range attrs.Metadata {
+	for k, v := range metadata {
 		md[k] = v
 	}
 	md["moderation"] = "approved"
 	md["firebaseStorageDownloadTokens"] = token
 
 	if _, err := dst.CopierFrom(src).Run(ctx); err != nil {
-		return fmt.Errorf("copy: %w", err)
+		return "", fmt.Errorf("copy: %w", err)
 	}
 	if _, err := dst.Update(ctx, storage.ObjectAttrsToUpdate{Metadata: md}); err != nil {
-		return fmt.Errorf("update metadata: %w", err)
+		return "", fmt.Errorf("update metadata: %w", err)
+	}
+	if err := src.Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return "", err
+	}
+	return token, nil
+}
+
+func (m *ModerationService) setObjectMetadata(ctx context.Context, name string, md map[string]string) error {
+	obj := m.gcs.Bucket(m.bucket).Object(name)
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		return err
+	}
+	next := map[string]string{}
+	for k, v := range attrs.Metadata {
+		next[k] = v
+	}
+	for k, v := range md {
+		next[k] = v
 	}
-	return src.Delete(ctx)
+	_, err = obj.Update(ctx, storage.ObjectAttrsToUpdate{Metadata: next})
+	return err
 }
 
 func (m *ModerationService) deleteObject(ctx context.Context, name string) error {
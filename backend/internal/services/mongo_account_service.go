@@ -16,6 +16,18 @@ type MongoAccountService struct {
 	salesCol     *mongo.Collection
 	itemsCol     *mongo.Collection
 	favoritesCol *mongo.Collection
+	followsCol   *mongo.Collection
+	searchesCol  *mongo.Collection
+	alertsCol    *mongo.Collection
+	wantedCol    *mongo.Collection
+	wantedMatCol *mongo.Collection
+	curbCol      *mongo.Collection
+	eventsCol    *mongo.Collection
+	eventMemCol  *mongo.Collection
+	devicesCol   *mongo.Collection
+	inboxCol     *mongo.Collection
+	holdsCol     *mongo.Collection
+	notifPrefCol *mongo.Collection
 	profilesCol  *mongo.Collection
 }
 
@@ -40,6 +52,18 @@ func NewMongoAccountService(ctx context.Context, mongoURI, dbName string) (*Mong
 		salesCol:     db.Collection("sales"),
 		itemsCol:     db.Collection("items"),
 		favoritesCol: db.Collection("favorites"),
+		followsCol:   db.Collection("follows"),
+		searchesCol:  db.Collection("saved_searches"),
+		alertsCol:    db.Collection("saved_search_alerts"),
+		wantedCol:    db.Collection("wanted_posts"),
+		wantedMatCol: db.Collection("wanted_matches"),
+		curbCol:      db.Collection("curb_alerts"),
+		eventsCol:    db.Collection("community_events"),
+		eventMemCol:  db.Collection("event_memberships"),
+		devicesCol:   db.Collection("device_tokens"),
+		inboxCol:     db.Collection("notifications"),
+		holdsCol:     db.Collection("notification_holds"),
+		notifPrefCol: db.Collection("notification_preferences"),
 		profilesCol:  db.Collection("profiles"),
 	}, nil
 }
@@ -56,9 +80,17 @@ type DeleteAccountResult struct {
 // DeleteAccount deletes all data associated with the given Firebase UID:
 // - profile doc
 // - favorites by user_id
-// - sales by user_id and their items
+// - sales by user_id and their items, and the user's co-host entries on other sales
 // - favorites pointing at those sales (by sale_id)
-// It returns Firebase image URLs (sale cover, item images, profile photo) to be deleted client-side.
+// - follows in either direction
+// - saved searches and their alerts
+// - wanted posts and matches on either side
+// - curb alerts
+// - community events they organize, and event memberships on either side
+// - push device tokens
+// - in-app notifications, held pushes and notification preferences
+// It returns Firebase image URLs (sale cover, item images, curb alert photos, profile photo) to be
+// deleted client-side.
 func (s *MongoAccountService) DeleteAccount(ctx context.Context, userID string) (*DeleteAccountResult, error) {
 	// Gather image URLs.
 	urls := make(map[string]struct{})
@@ -140,6 +172,32 @@ func (s *MongoAccountService) DeleteAccount(ctx context.Context, userID string)
 		}
 	}
 
+	// curb alert photos
+	{
+		cur, err := s.curbCol.Find(ctx, bson.M{"user_id": userID}, options.Find().SetProjection(bson.M{
+			"photo_url": 1,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		defer cur.Close(ctx)
+
+		for cur.Next(ctx) {
+			var d struct {
+				PhotoURL string `bson:"photo_url"`
+			}
+			if err := cur.Decode(&d); err != nil {
+				return nil, err
+			}
+			if d.PhotoURL != "" {
+				urls[d.PhotoURL] = struct{}{}
+			}
+		}
+		if err := cur.Err(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Deletes (order matters a bit to avoid leaving dangling pointers)
 	// 1) favorites by user_id OR favorites pointing at sale ids being removed
 	if len(saleIDs) > 0 {
@@ -158,10 +216,65 @@ func (s *MongoAccountService) DeleteAccount(ctx context.Context, userID string)
 		_, _ = s.itemsCol.DeleteMany(ctx, bson.M{"sale_id": bson.M{"$in": saleIDs}})
 	}
 
-	// 3) sales by user
+	// 3) sales by user, and co-host entries on other users' sales
 	_, _ = s.salesCol.DeleteMany(ctx, bson.M{"user_id": userID})
+	_, _ = s.salesCol.UpdateMany(ctx, bson.M{"co_hosts.user_id": userID}, bson.M{
+		"$pull": bson.M{"co_hosts": bson.M{"user_id": userID}},
+	})
+
+	// 3b) community events organized by the user, with all their memberships, and the
+	// user's own memberships in other events
+	{
+		eventIDs := make([]string, 0)
+		cur, err := s.eventsCol.Find(ctx, bson.M{"organizer_id": userID}, options.Find().SetProjection(bson.M{"_id": 1}))
+		if err == nil {
+			for cur.Next(ctx) {
+				var d struct {
+					ID string `bson:"_id"`
+				}
+				if cur.Decode(&d) == nil {
+					eventIDs = append(eventIDs, d.ID)
+				}
+			}
+			cur.Close(ctx)
+		}
+		if len(eventIDs) > 0 {
+			_, _ = s.eventMemCol.DeleteMany(ctx, bson.M{"event_id": bson.M{"$in": eventIDs}})
+		}
+		_, _ = s.eventsCol.DeleteMany(ctx, bson.M{"organizer_id": userID})
+		_, _ = s.eventMemCol.DeleteMany(ctx, bson.M{"seller_id": userID})
+	}
+
+	// 4) follows, both who the user follows and who follows them
+	_, _ = s.followsCol.DeleteMany(ctx, bson.M{
+		"$or": []bson.M{
+			{"follower_id": userID},
+			{"seller_id": userID},
+		},
+	})
+
+	// 5) saved searches, alerts, wanted posts and curb alerts
+	_, _ = s.searchesCol.DeleteMany(ctx, bson.M{"user_id": userID})
+	_, _ = s.alertsCol.DeleteMany(ctx, bson.M{"user_id": userID})
+	_, _ = s.wantedCol.DeleteMany(ctx, bson.M{"user_id": userID})
+	_, _ = s.wantedMatCol.DeleteMany(ctx, bson.M{
+		"$or": []bson.M{
+			{"buyer_id": userID},
+			{"seller_id": userID},
+		},
+	})
+
+	_, _ = s.curbCol.DeleteMany(ctx, bson.M{"user_id": userID})
+
+	// 6) push device tokens
+	_, _ = s.devicesCol.DeleteMany(ctx, bson.M{"user_id": userID})
+
+	// 7) in-app notifications and notification settings
+	_, _ = s.inboxCol.DeleteMany(ctx, bson.M{"user_id": userID})
+	_, _ = s.holdsCol.DeleteMany(ctx, bson.M{"user_id": userID})
+	_, _ = s.notifPrefCol.DeleteOne(ctx, bson.M{"_id": userID})
 
-	// 4) profile
+	// 8) profile
 	_, _ = s.profilesCol.DeleteOne(ctx, bson.M{"user_id": userID})
 
 	// Deduped list
@@ -0,0 +1,571 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoCommunityEventService struct {
+	client        *mongo.Client
+	db            *mongo.Database
+	eventsCol     *mongo.Collection
+	membersCol    *mongo.Collection
+	salesService  SalesService
+	notifications NotificationPublisher
+}
+
+type mongoEventPointDoc struct {
+	Latitude  float64 `bson:"latitude"`
+	Longitude float64 `bson:"longitude"`
+}
+
+// mongoCommunityEventDoc keeps the boundary's bounding box alongside the polygon so
+// map queries can use a plain range filter.
+type mongoCommunityEventDoc struct {
+	ID          string               `bson:"_id"`
+	OrganizerID string               `bson:"organizer_id"`
+	Title       string               `bson:"title"`
+	Description string               `bson:"description"`
+	Boundary    []mongoEventPointDoc `bson:"boundary"`
+	MinLat      float64              `bson:"min_lat"`
+	MaxLat      float64              `bson:"max_lat"`
+	MinLng      float64              `bson:"min_lng"`
+	MaxLng      float64              `bson:"max_lng"`
+	StartDate   time.Time            `bson:"start_date"`
+	EndDate     time.Time            `bson:"end_date"`
+	CreatedAt   time.Time            `bson:"created_at"`
+}
+
+type mongoEventMembershipDoc struct {
+	ID          string     `bson:"_id"` // event_id:sale_id
+	EventID     string     `bson:"event_id"`
+	SaleID      string     `bson:"sale_id"`
+	SellerID    string     `bson:"seller_id"`
+	OrganizerID string     `bson:"organizer_id"`
+	Status      string     `bson:"status"`
+	RequestedAt time.Time  `bson:"requested_at"`
+	DecidedAt   *time.Time `bson:"decided_at,omitempty"`
+}
+
+func NewMongoCommunityEventService(
+	ctx context.Context,
+	mongoURI string,
+	dbName string,
+	salesService SalesService,
+	notifications NotificationPublisher,
+) (*MongoCommunityEventService, error) {
+	if mongoURI == "" || dbName == "" || salesService == nil {
+		return nil, ErrEventBadInput
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	events := db.Collection("community_events")
+	members := db.Collection("event_memberships")
+
+	// Best-effort indexes.
+	_, _ = events.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "min_lat", Value: 1}, {Key: "max_lat", Value: 1}, {Key: "min_lng", Value: 1}, {Key: "max_lng", Value: 1}}},
+		{Keys: bson.D{{Key: "organizer_id", Value: 1}}},
+	})
+	_, _ = members.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "sale_id", Value: 1}}},
+		{Keys: bson.D{{Key: "seller_id", Value: 1}}},
+	})
+
+	log.Printf("MongoDB connected (community events): db=%s", dbName)
+	return &MongoCommunityEventService{
+		client:        client,
+		db:            db,
+		eventsCol:     events,
+		membersCol:    members,
+		salesService:  salesService,
+		notifications: notifications,
+	}, nil
+}
+
+func (s *MongoCommunityEventService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func communityEventDocToModel(d mongoCommunityEventDoc) *models.CommunityEvent {
+	boundary := make([]models.GeoPoint, 0, len(d.Boundary))
+	for _, p := range d.Boundary {
+		boundary = append(boundary, models.GeoPoint{Latitude: p.Latitude, Longitude: p.Longitude})
+	}
+	return &models.CommunityEvent{
+		ID:          d.ID,
+		OrganizerID: d.OrganizerID,
+		Title:       d.Title,
+		Description: d.Description,
+		Boundary:    boundary,
+		StartDate:   d.StartDate,
+		EndDate:     d.EndDate,
+		CreatedAt:   d.CreatedAt,
+	}
+}
+
+func eventMembershipDocToModel(d mongoEventMembershipDoc) *models.EventMembership {
+	return &models.EventMembership{
+		EventID:     d.EventID,
+		SaleID:      d.SaleID,
+		SellerID:    d.SellerID,
+		Status:      d.Status,
+		RequestedAt: d.RequestedAt,
+		DecidedAt:   d.DecidedAt,
+	}
+}
+
+func eventMembershipID(eventID, saleID string) string {
+	return eventID + ":" + saleID
+}
+
+// eventFields are the organizer-editable fields of an event document.
+func eventFields(req *models.CreateCommunityEventRequest) bson.M {
+	boundary := make([]mongoEventPointDoc, 0, len(req.Boundary))
+	for _, p := range req.Boundary {
+		boundary = append(boundary, mongoEventPointDoc{Latitude: p.Latitude, Longitude: p.Longitude})
+	}
+	ev := &models.CommunityEvent{Boundary: req.Boundary}
+	minLat, maxLat, minLng, maxLng := ev.Bounds()
+	return bson.M{
+		"title":       strings.TrimSpace(req.Title),
+		"description": strings.TrimSpace(req.Description),
+		"boundary":    boundary,
+		"min_lat":     minLat,
+		"max_lat":     maxLat,
+		"min_lng":     minLng,
+		"max_lng":     maxLng,
+		"start_date":  req.StartDate,
+		"end_date":    req.EndDate,
+	}
+}
+
+func (s *MongoCommunityEventService) CreateEvent(organizerID string, req *models.CreateCommunityEventRequest) (*models.CommunityEvent, error) {
+	if organizerID == "" {
+		return nil, ErrEventBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	id := uuid.New().String()
+	doc := eventFields(req)
+	doc["_id"] = id
+	doc["organizer_id"] = organizerID
+	doc["created_at"] = time.Now().UTC()
+
+	if _, err := s.eventsCol.InsertOne(ctx, doc); err != nil {
+		return nil, err
+	}
+	return s.getEvent(ctx, id)
+}
+
+func (s *MongoCommunityEventService) GetEvent(eventID string) (*models.CommunityEvent, error) {
+	if eventID == "" {
+		return nil, ErrEventBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return s.getEvent(ctx, eventID)
+}
+
+func (s *MongoCommunityEventService) getEvent(ctx context.Context, eventID string) (*models.CommunityEvent, error) {
+	var d mongoCommunityEventDoc
+	if err := s.eventsCol.FindOne(ctx, bson.M{"_id": eventID}).Decode(&d); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	return communityEventDocToModel(d), nil
+}
+
+// organizedEvent loads the event and checks that userID organizes it.
+func (s *MongoCommunityEventService) organizedEvent(ctx context.Context, organizerID, eventID string) (*models.CommunityEvent, error) {
+	event, err := s.getEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.OrganizerID != organizerID {
+		return nil, ErrEventForbidden
+	}
+	return event, nil
+}
+
+func (s *MongoCommunityEventService) UpdateEvent(organizerID, eventID string, req *models.CreateCommunityEventRequest) (*models.CommunityEvent, error) {
+	if organizerID == "" || eventID == "" {
+		return nil, ErrEventBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := s.organizedEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+	if _, err := s.eventsCol.UpdateOne(ctx, bson.M{"_id": eventID}, bson.M{"$set": eventFields(req)}); err != nil {
+		return nil, err
+	}
+	return s.getEvent(ctx, eventID)
+}
+
+func (s *MongoCommunityEventService) DeleteEvent(organizerID, eventID string) error {
+	if organizerID == "" || eventID == "" {
+		return ErrEventBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := s.organizedEvent(ctx, organizerID, eventID); err != nil {
+		return err
+	}
+	if _, err := s.eventsCol.DeleteOne(ctx, bson.M{"_id": eventID}); err != nil {
+		return err
+	}
+	_, _ = s.membersCol.DeleteMany(ctx, bson.M{"event_id": eventID})
+	return nil
+}
+
+func (s *MongoCommunityEventService) ListByBounds(minLat, maxLat, minLng, maxLng float64, limit int) ([]*models.CommunityEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return s.listByBounds(ctx, minLat, maxLat, minLng, maxLng, limit)
+}
+
+func (s *MongoCommunityEventService) listByBounds(ctx context.Context, minLat, maxLat, minLng, maxLng float64, limit int) ([]*models.CommunityEvent, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 200
+	}
+
+	// Overlap, not containment: an event straddling the edge of the map still shows.
+	filter := bson.M{
+		"min_lat": bson.M{"$lte": maxLat},
+		"max_lat": bson.M{"$gte": minLat},
+		"min_lng": bson.M{"$lte": maxLng},
+		"max_lng": bson.M{"$gte": minLng},
+	}
+
+	cur, err := s.eventsCol.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "start_date", Value: 1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.CommunityEvent, 0)
+	for cur.Next(ctx) {
+		var d mongoCommunityEventDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, communityEventDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *MongoCommunityEventService) ListClusters(minLat, maxLat, minLng, maxLng float64, limit int) ([]*models.EventCluster, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	events, err := s.listByBounds(ctx, minLat, maxLat, minLng, maxLng, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return []*models.EventCluster{}, nil
+	}
+
+	eventIDs := make([]string, 0, len(events))
+	for _, e := range events {
+		eventIDs = append(eventIDs, e.ID)
+	}
+	members, err := s.findMemberships(ctx, bson.M{
+		"event_id": bson.M{"$in": eventIDs},
+		"status":   models.EventMembershipApproved,
+	})
+	if err != nil {
+		return nil, err
+	}
+	saleIDsByEvent := make(map[string][]string)
+	for _, m := range members {
+		saleIDsByEvent[m.EventID] = append(saleIDsByEvent[m.EventID], m.SaleID)
+	}
+
+	out := make([]*models.EventCluster, 0, len(events))
+	for _, e := range events {
+		eMinLat, eMaxLat, eMinLng, eMaxLng := e.Bounds()
+		saleIDs := saleIDsByEvent[e.ID]
+		if saleIDs == nil {
+			saleIDs = []string{}
+		}
+		out = append(out, &models.EventCluster{
+			EventID:   e.ID,
+			Title:     e.Title,
+			StartDate: e.StartDate,
+			EndDate:   e.EndDate,
+			Latitude:  (eMinLat + eMaxLat) / 2,
+			Longitude: (eMinLng + eMaxLng) / 2,
+			MinLat:    eMinLat,
+			MaxLat:    eMaxLat,
+			MinLng:    eMinLng,
+			MaxLng:    eMaxLng,
+			SaleCount: len(saleIDs),
+			SaleIDs:   saleIDs,
+		})
+	}
+	return out, nil
+}
+
+func (s *MongoCommunityEventService) ListMemberSales(eventID string) ([]*models.GarageSale, error) {
+	if eventID == "" {
+		return nil, ErrEventBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	members, err := s.findMemberships(ctx, bson.M{"event_id": eventID, "status": models.EventMembershipApproved})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*models.GarageSale, 0, len(members))
+	for _, m := range members {
+		sale, err := s.salesService.GetByID(m.SaleID)
+		if err != nil || sale.Hidden {
+			// Skip missing sales (deleted/inaccessible) and sales hidden by moderation.
+			continue
+		}
+		out = append(out, sale)
+	}
+	return out, nil
+}
+
+func (s *MongoCommunityEventService) RequestJoin(sellerID, eventID, saleID string) (*models.EventMembership, error) {
+	if sellerID == "" || eventID == "" || saleID == "" {
+		return nil, ErrEventBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	event, err := s.getEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	sale, err := s.salesService.GetByID(saleID)
+	if err != nil {
+		return nil, err
+	}
+	if sale.UserID != sellerID {
+		return nil, ErrUnauthorized
+	}
+	if err := saleFitsEvent(event, sale); err != nil {
+		return nil, err
+	}
+
+	id := eventMembershipID(eventID, saleID)
+	var existing mongoEventMembershipDoc
+	err = s.membersCol.FindOne(ctx, bson.M{"_id": id}).Decode(&existing)
+	if err == nil && existing.Status != models.EventMembershipRejected {
+		return nil, ErrEventMembershipFound
+	}
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	// Organizers add their own sales without a round trip; a rejected sale may ask again.
+	now := time.Now().UTC()
+	doc := mongoEventMembershipDoc{
+		ID:          id,
+		EventID:     eventID,
+		SaleID:      saleID,
+		SellerID:    sellerID,
+		OrganizerID: event.OrganizerID,
+		Status:      models.EventMembershipPending,
+		RequestedAt: now,
+	}
+	if sellerID == event.OrganizerID {
+		doc.Status = models.EventMembershipApproved
+		doc.DecidedAt = &now
+	}
+	if _, err := s.membersCol.ReplaceOne(ctx, bson.M{"_id": id}, doc, options.Replace().SetUpsert(true)); err != nil {
+		return nil, err
+	}
+
+	if doc.Status == models.EventMembershipPending {
+		s.notify(ctx, &models.Notification{
+			UserID: event.OrganizerID,
+			Type:   models.NotificationEventJoinRequested,
+			Title:  fmt.Sprintf("New sale wants to join %s", event.Title),
+			Body:   sale.Title,
+			Data:   map[string]string{"event_id": eventID, "sale_id": saleID},
+		})
+	}
+	return eventMembershipDocToModel(doc), nil
+}
+
+func (s *MongoCommunityEventService) ListRequests(organizerID, eventID, status string) ([]*models.EventMembership, error) {
+	if organizerID == "" || eventID == "" {
+		return nil, ErrEventBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := s.organizedEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+	filter := bson.M{"event_id": eventID}
+	if status != "" {
+		filter["status"] = status
+	}
+	return s.findMemberships(ctx, filter)
+}
+
+func (s *MongoCommunityEventService) DecideRequest(organizerID, eventID, saleID string, approve bool) (*models.EventMembership, error) {
+	if organizerID == "" || eventID == "" || saleID == "" {
+		return nil, ErrEventBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	event, err := s.organizedEvent(ctx, organizerID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.EventMembershipRejected
+	if approve {
+		status = models.EventMembershipApproved
+	}
+	now := time.Now().UTC()
+
+	var d mongoEventMembershipDoc
+	err = s.membersCol.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": eventMembershipID(eventID, saleID)},
+		bson.M{"$set": bson.M{"status": status, "decided_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&d)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrEventMembershipNone
+		}
+		return nil, err
+	}
+
+	title := fmt.Sprintf("Your sale joined %s", event.Title)
+	if !approve {
+		title = fmt.Sprintf("Your request to join %s was declined", event.Title)
+	}
+	s.notify(ctx, &models.Notification{
+		UserID: d.SellerID,
+		Type:   models.NotificationEventJoinDecided,
+		Title:  title,
+		Body:   event.Title,
+		Data:   map[string]string{"event_id": eventID, "sale_id": saleID, "status": status},
+	})
+	return eventMembershipDocToModel(d), nil
+}
+
+func (s *MongoCommunityEventService) RemoveSale(userID, eventID, saleID string) error {
+	if userID == "" || eventID == "" || saleID == "" {
+		return ErrEventBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := s.membersCol.DeleteOne(ctx, bson.M{
+		"_id": eventMembershipID(eventID, saleID),
+		"$or": []bson.M{
+			{"seller_id": userID},
+			{"organizer_id": userID},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrEventMembershipNone
+	}
+	return nil
+}
+
+// HandleSaleEvent drops a deleted sale from every event it belonged to.
+func (s *MongoCommunityEventService) HandleSaleEvent(ctx context.Context, ev SaleEvent) {
+	if ev.Type != SaleEventDeleted || ev.Sale == nil {
+		return
+	}
+	if _, err := s.membersCol.DeleteMany(ctx, bson.M{"sale_id": ev.Sale.ID}); err != nil {
+		log.Printf("[events] remove memberships sale=%s err=%v", ev.Sale.ID, err)
+	}
+}
+
+func (s *MongoCommunityEventService) findMemberships(ctx context.Context, filter bson.M) ([]*models.EventMembership, error) {
+	cur, err := s.membersCol.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "requested_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.EventMembership, 0)
+	for cur.Next(ctx) {
+		var d mongoEventMembershipDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, eventMembershipDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *MongoCommunityEventService) notify(ctx context.Context, n *models.Notification) {
+	if s.notifications == nil || n.UserID == "" {
+		return
+	}
+	if err := s.notifications.Publish(ctx, n); err != nil {
+		log.Printf("[events] notify user=%s type=%s err=%v", n.UserID, n.Type, err)
+	}
+}
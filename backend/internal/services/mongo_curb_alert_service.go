@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+// curbAlertGoneGrace is how long a gone alert lingers (for its poster's list) before the
+// TTL index removes it.
+const curbAlertGoneGrace = 30 * time.Minute
+
+type MongoCurbAlertService struct {
+	client    *mongo.Client
+	db        *mongo.Database
+	alertsCol *mongo.Collection
+}
+
+type mongoCurbAlertDoc struct {
+	ID          string     `bson:"_id"`
+	UserID      string     `bson:"user_id"`
+	Title       string     `bson:"title"`
+	Description string     `bson:"description,omitempty"`
+	PhotoURL    string     `bson:"photo_url"`
+	Latitude    float64    `bson:"latitude"`
+	Longitude   float64    `bson:"longitude"`
+	Address     string     `bson:"address,omitempty"`
+	CreatedAt   time.Time  `bson:"created_at"`
+	ExpiresAt   time.Time  `bson:"expires_at"`
+	GoneAt      *time.Time `bson:"gone_at,omitempty"`
+	GoneBy      string     `bson:"gone_by,omitempty"`
+}
+
+func NewMongoCurbAlertService(ctx context.Context, mongoURI, dbName string) (*MongoCurbAlertService, error) {
+	if mongoURI == "" || dbName == "" {
+		return nil, ErrCurbAlertBadInput
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	alerts := db.Collection("curb_alerts")
+
+	// Best-effort indexes. The TTL index deletes alerts once expires_at passes; reads
+	// also filter on it because the TTL monitor only runs about once a minute.
+	_, _ = alerts.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+		{Keys: bson.D{{Key: "latitude", Value: 1}, {Key: "longitude", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+
+	log.Printf("MongoDB connected (curb alerts): db=%s", dbName)
+	return &MongoCurbAlertService{
+		client:    client,
+		db:        db,
+		alertsCol: alerts,
+	}, nil
+}
+
+func (s *MongoCurbAlertService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func curbAlertDocToModel(d mongoCurbAlertDoc) *models.CurbAlert {
+	return &models.CurbAlert{
+		ID:          d.ID,
+		UserID:      d.UserID,
+		Title:       d.Title,
+		Description: d.Description,
+		PhotoURL:    d.PhotoURL,
+		Latitude:    d.Latitude,
+		Longitude:   d.Longitude,
+		Address:     d.Address,
+		CreatedAt:   d.CreatedAt,
+		ExpiresAt:   d.ExpiresAt,
+		GoneAt:      d.GoneAt,
+	}
+}
+
+func (s *MongoCurbAlertService) CreateCurbAlert(userID string, req *models.CreateCurbAlertRequest) (*models.CurbAlert, error) {
+	if userID == "" {
+		return nil, ErrCurbAlertBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	count, err := s.alertsCol.CountDocuments(ctx, bson.M{
+		"user_id":    userID,
+		"expires_at": bson.M{"$gt": now},
+		"gone_at":    bson.M{"$exists": false},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxActiveCurbAlertsPerUser {
+		return nil, ErrCurbAlertLimit
+	}
+
+	hours := req.ExpiresInHours
+	if hours <= 0 {
+		hours = models.DefaultCurbAlertHours
+	}
+
+	doc := mongoCurbAlertDoc{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		PhotoURL:    req.PhotoURL,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		Address:     strings.TrimSpace(req.Address),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Duration(hours) * time.Hour),
+	}
+
+	if _, err := s.alertsCol.InsertOne(ctx, doc); err != nil {
+		return nil, err
+	}
+	return curbAlertDocToModel(doc), nil
+}
+
+func (s *MongoCurbAlertService) GetCurbAlert(alertID string) (*models.CurbAlert, error) {
+	if alertID == "" {
+		return nil, ErrCurbAlertBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var d mongoCurbAlertDoc
+	err := s.alertsCol.FindOne(ctx, bson.M{
+		"_id":        alertID,
+		"expires_at": bson.M{"$gt": time.Now().UTC()},
+	}).Decode(&d)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrCurbAlertNotFound
+		}
+		return nil, err
+	}
+	return curbAlertDocToModel(d), nil
+}
+
+func (s *MongoCurbAlertService) ListByBounds(minLat, maxLat, minLng, maxLng float64, limit int) ([]*models.CurbAlert, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if limit <= 0 || limit > 500 {
+		limit = 500
+	}
+
+	filter := bson.M{
+		"latitude":   bson.M{"$gte": minLat, "$lte": maxLat},
+		"longitude":  bson.M{"$gte": minLng, "$lte": maxLng},
+		"expires_at": bson.M{"$gt": time.Now().UTC()},
+		"gone_at":    bson.M{"$exists": false},
+	}
+	return s.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit)))
+}
+
+func (s *MongoCurbAlertService) ListMine(userID string) ([]*models.CurbAlert, error) {
+	if userID == "" {
+		return nil, ErrCurbAlertBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id":    userID,
+		"expires_at": bson.M{"$gt": time.Now().UTC()},
+	}
+	return s.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+}
+
+func (s *MongoCurbAlertService) MarkGone(userID, alertID string) (*models.CurbAlert, error) {
+	if userID == "" || alertID == "" {
+		return nil, ErrCurbAlertBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Gone alerts drop off the map immediately and expire shortly after. Marking an
+	// already-gone alert again is a no-op that returns it unchanged.
+	now := time.Now().UTC()
+	var d mongoCurbAlertDoc
+	err := s.alertsCol.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": alertID, "expires_at": bson.M{"$gt": now}, "gone_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"gone_at": now, "gone_by": userID, "expires_at": now.Add(curbAlertGoneGrace)}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&d)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return s.GetCurbAlert(alertID)
+		}
+		return nil, err
+	}
+	return curbAlertDocToModel(d), nil
+}
+
+func (s *MongoCurbAlertService) DeleteCurbAlert(userID, alertID string) error {
+	if userID == "" || alertID == "" {
+		return ErrCurbAlertBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := s.alertsCol.DeleteOne(ctx, bson.M{"_id": alertID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrCurbAlertNotFound
+	}
+	return nil
+}
+
+func (s *MongoCurbAlertService) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*models.CurbAlert, error) {
+	cur, err := s.alertsCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.CurbAlert, 0)
+	for cur.Next(ctx) {
+		var d mongoCurbAlertDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, curbAlertDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApprovePendingCurbPhoto points any alert whose photo is pendingPath at approvedURL.
+func (s *MongoCurbAlertService) ApprovePendingCurbPhoto(ctx context.Context, pendingPath, approvedURL string) error {
+	if strings.TrimSpace(pendingPath) == "" || strings.TrimSpace(approvedURL) == "" {
+		return nil
+	}
+	_, err := s.alertsCol.UpdateMany(ctx, bson.M{"photo_url": pendingPath}, bson.M{
+		"$set": bson.M{"photo_url": approvedURL},
+	})
+	return err
+}
+
+// RejectPendingCurbPhoto deletes alerts whose photo is pendingPath. A curb alert is
+// only its photo, so there is nothing left to show without it.
+func (s *MongoCurbAlertService) RejectPendingCurbPhoto(ctx context.Context, pendingPath string) error {
+	if strings.TrimSpace(pendingPath) == "" {
+		return nil
+	}
+	_, err := s.alertsCol.DeleteMany(ctx, bson.M{"photo_url": pendingPath})
+	return err
+}
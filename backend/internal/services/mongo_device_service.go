@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoDeviceService struct {
+	client     *mongo.Client
+	db         *mongo.Database
+	devicesCol *mongo.Collection
+}
+
+type mongoDeviceDoc struct {
+	Token     string    `bson:"_id"`
+	UserID    string    `bson:"user_id"`
+	Platform  string    `bson:"platform"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+func NewMongoDeviceService(ctx context.Context, mongoURI, dbName string) (*MongoDeviceService, error) {
+	if mongoURI == "" || dbName == "" {
+		return nil, ErrDeviceBadInput
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	devices := db.Collection("device_tokens")
+
+	// Best-effort indexes. The token itself is the _id.
+	_, _ = devices.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}},
+	})
+
+	log.Printf("MongoDB connected (devices): db=%s", dbName)
+	return &MongoDeviceService{
+		client:     client,
+		db:         db,
+		devicesCol: devices,
+	}, nil
+}
+
+func (s *MongoDeviceService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func (s *MongoDeviceService) RegisterDevice(userID string, req *models.RegisterDeviceRequest) (*models.DeviceToken, error) {
+	token := strings.TrimSpace(req.Token)
+	if userID == "" || token == "" {
+		return nil, ErrDeviceBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	res := s.devicesCol.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": token},
+		bson.M{
+			"$set":         bson.M{"user_id": userID, "platform": req.Platform, "updated_at": now},
+			"$setOnInsert": bson.M{"created_at": now},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	)
+
+	var doc mongoDeviceDoc
+	if err := res.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return &models.DeviceToken{
+		Token:     doc.Token,
+		UserID:    doc.UserID,
+		Platform:  doc.Platform,
+		CreatedAt: doc.CreatedAt,
+		UpdatedAt: doc.UpdatedAt,
+	}, nil
+}
+
+func (s *MongoDeviceService) UnregisterDevice(userID, token string) error {
+	if userID == "" || token == "" {
+		return ErrDeviceBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := s.devicesCol.DeleteOne(ctx, bson.M{"_id": token, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
+
+func (s *MongoDeviceService) ListUserTokens(ctx context.Context, userID string) ([]string, error) {
+	cur, err := s.devicesCol.Find(ctx, bson.M{"user_id": userID}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]string, 0)
+	for cur.Next(ctx) {
+		var doc struct {
+			Token string `bson:"_id"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		out = append(out, doc.Token)
+	}
+	return out, cur.Err()
+}
+
+func (s *MongoDeviceService) RemoveTokens(ctx context.Context, tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	_, err := s.devicesCol.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": tokens}})
+	return err
+}
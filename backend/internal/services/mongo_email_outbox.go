@@ -0,0 +1,304 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+// MongoEmailOutbox is a Mailer that durably queues messages in the "email_outbox"
+// collection and delivers them in the background through another Mailer. Send returns
+// once the message is stored, so a provider outage never loses mail.
+type MongoEmailOutbox struct {
+	client    *mongo.Client
+	db        *mongo.Database
+	outboxCol *mongo.Collection
+	delivery  Mailer
+}
+
+type mongoOutboxEmailDoc struct {
+	ID            string            `bson:"_id"`
+	To            string            `bson:"to"`
+	ToName        string            `bson:"to_name,omitempty"`
+	ReplyTo       string            `bson:"reply_to,omitempty"`
+	ReplyToName   string            `bson:"reply_to_name,omitempty"`
+	FromName      string            `bson:"from_name,omitempty"`
+	Subject       string            `bson:"subject"`
+	Text          string            `bson:"text,omitempty"`
+	HTML          string            `bson:"html,omitempty"`
+	Tags          map[string]string `bson:"tags,omitempty"`
+	Status        string            `bson:"status"`
+	Attempts      int               `bson:"attempts"`
+	LastError     string            `bson:"last_error,omitempty"`
+	NextAttemptAt time.Time         `bson:"next_attempt_at"`
+	LeaseUntil    *time.Time        `bson:"lease_until,omitempty"`
+	CreatedAt     time.Time         `bson:"created_at"`
+	SentAt        *time.Time        `bson:"sent_at,omitempty"`
+	// ExpiresAt is only set once sent; the TTL index then purges the message.
+	ExpiresAt *time.Time `bson:"expires_at,omitempty"`
+}
+
+func NewMongoEmailOutbox(ctx context.Context, mongoURI, dbName string, delivery Mailer) (*MongoEmailOutbox, error) {
+	if mongoURI == "" || dbName == "" || delivery == nil {
+		return nil, ErrOutboxBadInput
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	col := db.Collection("email_outbox")
+
+	// Best-effort indexes.
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+
+	log.Printf("MongoDB connected (email outbox): db=%s", dbName)
+	return &MongoEmailOutbox{
+		client:    client,
+		db:        db,
+		outboxCol: col,
+		delivery:  delivery,
+	}, nil
+}
+
+func (o *MongoEmailOutbox) Close(ctx context.Context) error {
+	return o.client.Disconnect(ctx)
+}
+
+func outboxEmailDocToModel(d mongoOutboxEmailDoc) *models.OutboxEmail {
+	return &models.OutboxEmail{
+		ID:            d.ID,
+		To:            d.To,
+		Subject:       d.Subject,
+		Tags:          d.Tags,
+		Status:        d.Status,
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+		SentAt:        d.SentAt,
+	}
+}
+
+// Send queues msg for background delivery.
+func (o *MongoEmailOutbox) Send(ctx context.Context, msg *EmailMessage) error {
+	if err := validateEmailMessage(msg); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	doc := mongoOutboxEmailDoc{
+		ID:            uuid.New().String(),
+		To:            msg.To,
+		ToName:        msg.ToName,
+		ReplyTo:       msg.ReplyTo,
+		ReplyToName:   msg.ReplyToName,
+		FromName:      msg.FromName,
+		Subject:       msg.Subject,
+		Text:          msg.Text,
+		HTML:          msg.HTML,
+		Tags:          msg.Tags,
+		Status:        models.OutboxEmailPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	_, err := o.outboxCol.InsertOne(ctx, doc)
+	return err
+}
+
+// Run delivers due messages every interval until ctx is cancelled.
+func (o *MongoEmailOutbox) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			o.deliverDue(ctx)
+		}
+	}
+}
+
+func (o *MongoEmailOutbox) deliverDue(ctx context.Context) {
+	for {
+		doc, err := o.claim(ctx)
+		if err != nil {
+			if err != mongo.ErrNoDocuments {
+				log.Printf("[outbox] claim err=%v", err)
+			}
+			return
+		}
+		o.deliver(ctx, doc)
+	}
+}
+
+// claim leases the next due message. Messages stuck in "sending" past their lease (e.g.
+// the instance died mid-send) are claimed again.
+func (o *MongoEmailOutbox) claim(ctx context.Context) (*mongoOutboxEmailDoc, error) {
+	now := time.Now().UTC()
+	lease := now.Add(outboxLease)
+
+	res := o.outboxCol.FindOneAndUpdate(
+		ctx,
+		bson.M{"$or": []bson.M{
+			{"status": models.OutboxEmailPending, "next_attempt_at": bson.M{"$lte": now}},
+			{"status": models.OutboxEmailSending, "lease_until": bson.M{"$lte": now}},
+		}},
+		bson.M{"$set": bson.M{"status": models.OutboxEmailSending, "lease_until": lease}},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+			SetReturnDocument(options.After),
+	)
+
+	var doc mongoOutboxEmailDoc
+	if err := res.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (o *MongoEmailOutbox) deliver(ctx context.Context, doc *mongoOutboxEmailDoc) {
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	err := o.delivery.Send(sendCtx, &EmailMessage{
+		To:          doc.To,
+		ToName:      doc.ToName,
+		ReplyTo:     doc.ReplyTo,
+		ReplyToName: doc.ReplyToName,
+		FromName:    doc.FromName,
+		Subject:     doc.Subject,
+		Text:        doc.Text,
+		HTML:        doc.HTML,
+		Tags:        doc.Tags,
+	})
+	cancel()
+
+	now := time.Now().UTC()
+	var update bson.M
+	if err == nil {
+		expires := now.Add(outboxSentRetention)
+		update = bson.M{
+			"$set":   bson.M{"status": models.OutboxEmailSent, "sent_at": now, "expires_at": expires},
+			"$inc":   bson.M{"attempts": 1},
+			"$unset": bson.M{"lease_until": "", "last_error": ""},
+		}
+	} else {
+		attempts := doc.Attempts + 1
+		status := models.OutboxEmailPending
+		if attempts >= outboxMaxAttempts {
+			status = models.OutboxEmailDead
+			log.Printf("[outbox] dead-lettered id=%s to=%s attempts=%d err=%v", doc.ID, doc.To, attempts, err)
+		} else {
+			log.Printf("[outbox] send failed id=%s to=%s attempt=%d err=%v", doc.ID, doc.To, attempts, err)
+		}
+		update = bson.M{
+			"$set": bson.M{
+				"status":          status,
+				"attempts":        attempts,
+				"last_error":      err.Error(),
+				"next_attempt_at": now.Add(outboxBackoff(attempts)),
+			},
+			"$unset": bson.M{"lease_until": ""},
+		}
+	}
+
+	if _, uerr := o.outboxCol.UpdateOne(ctx, bson.M{"_id": doc.ID}, update); uerr != nil {
+		log.Printf("[outbox] update id=%s err=%v", doc.ID, uerr)
+	}
+}
+
+func (o *MongoEmailOutbox) ListEmails(status string, limit int) ([]*models.OutboxEmail, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	cur, err := o.outboxCol.Find(
+		ctx,
+		filter,
+		options.Find().
+			SetSort(bson.D{{Key: "created_at", Value: -1}}).
+			SetLimit(int64(limit)).
+			SetProjection(bson.M{"text": 0, "html": 0}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.OutboxEmail, 0)
+	for cur.Next(ctx) {
+		var d mongoOutboxEmailDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, outboxEmailDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (o *MongoEmailOutbox) RetryEmail(id string) (*models.OutboxEmail, error) {
+	if id == "" {
+		return nil, ErrOutboxBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Reset attempts so a retried dead letter gets a full retry budget.
+	res := o.outboxCol.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": id, "status": bson.M{"$in": []string{models.OutboxEmailDead, models.OutboxEmailPending}}},
+		bson.M{"$set": bson.M{
+			"status":          models.OutboxEmailPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now().UTC(),
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"text": 0, "html": 0}),
+	)
+
+	var updated mongoOutboxEmailDoc
+	if err := res.Decode(&updated); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrOutboxEmailNotFound
+		}
+		return nil, err
+	}
+	return outboxEmailDocToModel(updated), nil
+}
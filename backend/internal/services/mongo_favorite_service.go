@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"log"
 	"time"
 
@@ -15,10 +16,11 @@ import (
 )
 
 type MongoFavoriteService struct {
-	client       *mongo.Client
-	db           *mongo.Database
-	favoritesCol *mongo.Collection
-	salesService SalesService
+	client        *mongo.Client
+	db            *mongo.Database
+	favoritesCol  *mongo.Collection
+	salesService  SalesService
+	notifications NotificationPublisher
 }
 
 type mongoFavoriteDoc struct {
@@ -33,6 +35,7 @@ func NewMongoFavoriteService(
 	mongoURI string,
 	dbName string,
 	salesService SalesService,
+	notifications NotificationPublisher,
 ) (*MongoFavoriteService, error) {
 	if mongoURI == "" || dbName == "" {
 		return nil, ErrFavoriteBadInput
@@ -55,10 +58,11 @@ func NewMongoFavoriteService(
 	favs := db.Collection("favorites")
 
 	svc := &MongoFavoriteService{
-		client:       client,
-		db:           db,
-		favoritesCol: favs,
-		salesService: salesService,
+		client:        client,
+		db:            db,
+		favoritesCol:  favs,
+		salesService:  salesService,
+		notifications: notifications,
 	}
 
 	// Best-effort indexes.
@@ -68,6 +72,7 @@ func NewMongoFavoriteService(
 			Options: options.Index().SetUnique(true),
 		},
 		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "sale_id", Value: 1}}},
 		{Keys: bson.D{{Key: "created_at", Value: -1}}},
 	})
 
@@ -196,7 +201,84 @@ func (s *MongoFavoriteService) ListUserFavoriteSales(userID string) ([]*models.G
 			}
 			return nil, err
 		}
+		if sale.Hidden {
+			continue
+		}
 		out = append(out, sale)
 	}
 	return out, nil
 }
+
+func (s *MongoFavoriteService) listFavoriterIDs(ctx context.Context, saleID string) ([]string, error) {
+	cur, err := s.favoritesCol.Find(ctx, bson.M{"sale_id": saleID}, options.Find().SetProjection(bson.M{"user_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]string, 0)
+	for cur.Next(ctx) {
+		var doc mongoFavoriteDoc
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		out = append(out, doc.UserID)
+	}
+	return out, cur.Err()
+}
+
+// HandleSaleEvent notifies everyone who favorited a sale when it starts, is
+// rescheduled or is cancelled.
+func (s *MongoFavoriteService) HandleSaleEvent(ctx context.Context, ev SaleEvent) {
+	var typ, title, body string
+	switch ev.Type {
+	case SaleEventStarted:
+		typ = models.NotificationFavoriteStarted
+		title = "A sale you saved is open now"
+		body = fmt.Sprintf("%s has started", ev.Sale.Title)
+	case SaleEventRescheduled:
+		typ = models.NotificationFavoriteRescheduled
+		title = "A sale you saved has new dates"
+		body = fmt.Sprintf("%s now runs %s – %s", ev.Sale.Title,
+			ev.Sale.StartDate.Format("Mon Jan 2 3:04PM"), ev.Sale.EndDate.Format("Mon Jan 2 3:04PM"))
+	case SaleEventDeleted:
+		typ = models.NotificationFavoriteCancelled
+		title = "A sale you saved was cancelled"
+		body = fmt.Sprintf("%s has been cancelled", ev.Sale.Title)
+	default:
+		return
+	}
+
+	userIDs, err := s.listFavoriterIDs(ctx, ev.Sale.ID)
+	if err != nil {
+		log.Printf("[favorites] list favoriters sale=%s err=%v", ev.Sale.ID, err)
+		return
+	}
+
+	if s.notifications != nil {
+		for _, userID := range userIDs {
+			if userID == ev.ActorID {
+				continue
+			}
+			n := &models.Notification{
+				ID:        uuid.New().String(),
+				UserID:    userID,
+				Type:      typ,
+				Title:     title,
+				Body:      body,
+				Data:      map[string]string{"sale_id": ev.Sale.ID},
+				CreatedAt: ev.At,
+			}
+			if err := s.notifications.Publish(ctx, n); err != nil {
+				log.Printf("[favorites] notify user=%s sale=%s err=%v", userID, ev.Sale.ID, err)
+			}
+		}
+	}
+
+	// Favorites of a deleted sale are dead weight once everyone has been told.
+	if ev.Type == SaleEventDeleted {
+		if _, err := s.favoritesCol.DeleteMany(ctx, bson.M{"sale_id": ev.Sale.ID}); err != nil {
+			log.Printf("[favorites] cleanup sale=%s err=%v", ev.Sale.ID, err)
+		}
+	}
+}
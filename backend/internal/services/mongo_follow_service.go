@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoFollowService struct {
+	client        *mongo.Client
+	db            *mongo.Database
+	followsCol    *mongo.Collection
+	salesService  SalesService
+	profiles      *MongoProfileService
+	notifications NotificationPublisher
+}
+
+type mongoFollowDoc struct {
+	ID         string    `bson:"_id"`
+	FollowerID string    `bson:"follower_id"`
+	SellerID   string    `bson:"seller_id"`
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+func NewMongoFollowService(
+	ctx context.Context,
+	mongoURI string,
+	dbName string,
+	salesService SalesService,
+	profiles *MongoProfileService,
+	notifications NotificationPublisher,
+) (*MongoFollowService, error) {
+	if mongoURI == "" || dbName == "" {
+		return nil, ErrFollowBadInput
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	follows := db.Collection("follows")
+
+	svc := &MongoFollowService{
+		client:        client,
+		db:            db,
+		followsCol:    follows,
+		salesService:  salesService,
+		profiles:      profiles,
+		notifications: notifications,
+	}
+
+	// Best-effort indexes.
+	_, _ = follows.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "follower_id", Value: 1}, {Key: "seller_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "seller_id", Value: 1}}},
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+	})
+
+	log.Printf("MongoDB connected (follows): db=%s", dbName)
+	return svc, nil
+}
+
+func (s *MongoFollowService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func (s *MongoFollowService) Follow(followerID, sellerID string) (*models.Follow, error) {
+	if followerID == "" || sellerID == "" {
+		return nil, ErrFollowBadInput
+	}
+	if followerID == sellerID {
+		return nil, ErrFollowSelf
+	}
+	if _, err := s.profiles.GetByUserID(context.Background(), sellerID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrFollowSellerNotFound
+		}
+		return nil, err
+	}
+
+	doc := &mongoFollowDoc{
+		ID:         uuid.New().String(),
+		FollowerID: followerID,
+		SellerID:   sellerID,
+		CreatedAt:  time.Now(),
+	}
+
+	if _, err := s.followsCol.InsertOne(context.Background(), doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrAlreadyFollowing
+		}
+		return nil, err
+	}
+
+	return &models.Follow{
+		ID:         doc.ID,
+		FollowerID: doc.FollowerID,
+		SellerID:   doc.SellerID,
+		CreatedAt:  doc.CreatedAt,
+	}, nil
+}
+
+func (s *MongoFollowService) Unfollow(followerID, sellerID string) error {
+	if followerID == "" || sellerID == "" {
+		return ErrFollowBadInput
+	}
+
+	res, err := s.followsCol.DeleteOne(context.Background(), bson.M{
+		"follower_id": followerID,
+		"seller_id":   sellerID,
+	})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrFollowNotFound
+	}
+	return nil
+}
+
+func (s *MongoFollowService) ListFollowing(followerID string) ([]*models.Follow, error) {
+	if followerID == "" {
+		return nil, ErrFollowBadInput
+	}
+
+	cur, err := s.followsCol.Find(
+		context.Background(),
+		bson.M{"follower_id": followerID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	out := make([]*models.Follow, 0)
+	for cur.Next(context.Background()) {
+		var doc mongoFollowDoc
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		out = append(out, &models.Follow{
+			ID:         doc.ID,
+			FollowerID: doc.FollowerID,
+			SellerID:   doc.SellerID,
+			CreatedAt:  doc.CreatedAt,
+		})
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *MongoFollowService) ListFollowingSales(followerID string, limit int) ([]*models.GarageSale, error) {
+	follows, err := s.ListFollowing(followerID)
+	if err != nil {
+		return nil, err
+	}
+
+	sellerIDs := make([]string, 0, len(follows))
+	for _, f := range follows {
+		sellerIDs = append(sellerIDs, f.SellerID)
+	}
+	return s.salesService.ListUpcomingByUsers(sellerIDs, limit)
+}
+
+func (s *MongoFollowService) GetFollowCounts(userID string) (int, int, error) {
+	if userID == "" {
+		return 0, 0, ErrFollowBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	followers, err := s.followsCol.CountDocuments(ctx, bson.M{"seller_id": userID})
+	if err != nil {
+		return 0, 0, err
+	}
+	following, err := s.followsCol.CountDocuments(ctx, bson.M{"follower_id": userID})
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(followers), int(following), nil
+}
+
+func (s *MongoFollowService) listFollowerIDs(ctx context.Context, sellerID string) ([]string, error) {
+	cur, err := s.followsCol.Find(
+		ctx,
+		bson.M{"seller_id": sellerID},
+		options.Find().SetProjection(bson.M{"follower_id": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]string, 0)
+	for cur.Next(ctx) {
+		var doc mongoFollowDoc
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		out = append(out, doc.FollowerID)
+	}
+	return out, cur.Err()
+}
+
+// HandleSaleEvent notifies a seller's followers when they create or start a sale.
+func (s *MongoFollowService) HandleSaleEvent(ctx context.Context, ev SaleEvent) {
+	if s.notifications == nil {
+		return
+	}
+
+	var typ, title, body string
+	switch ev.Type {
+	case SaleEventCreated:
+		typ = models.NotificationFollowedSaleCreated
+		title = "New sale from a seller you follow"
+		body = fmt.Sprintf("%s starts %s", ev.Sale.Title, ev.Sale.StartDate.Format("Mon Jan 2"))
+	case SaleEventStarted:
+		typ = models.NotificationFollowedSaleStarted
+		title = "A seller you follow is open now"
+		body = fmt.Sprintf("%s has started", ev.Sale.Title)
+	default:
+		return
+	}
+
+	followerIDs, err := s.listFollowerIDs(ctx, ev.Sale.UserID)
+	if err != nil {
+		log.Printf("[follows] list followers seller=%s err=%v", ev.Sale.UserID, err)
+		return
+	}
+
+	for _, followerID := range followerIDs {
+		n := &models.Notification{
+			ID:     uuid.New().String(),
+			UserID: followerID,
+			Type:   typ,
+			Title:  title,
+			Body:   body,
+			Data: map[string]string{
+				"sale_id":   ev.Sale.ID,
+				"seller_id": ev.Sale.UserID,
+			},
+			CreatedAt: ev.At,
+		}
+		if err := s.notifications.Publish(ctx, n); err != nil {
+			log.Printf("[follows] notify follower=%s sale=%s err=%v", followerID, ev.Sale.ID, err)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoModerationAppealService struct {
+	client     *mongo.Client
+	db         *mongo.Database
+	appealsCol *mongo.Collection
+}
+
+type mongoModerationAppealDoc struct {
+	ID         string     `bson:"_id"`
+	DecisionID string     `bson:"decision_id"`
+	UserID     string     `bson:"user_id"`
+	TargetType string     `bson:"target_type"`
+	TargetID   string     `bson:"target_id"`
+	UploadType string     `bson:"upload_type,omitempty"`
+	Message    string     `bson:"message"`
+	Status     string     `bson:"status"`
+	ReviewerID string     `bson:"reviewer_id,omitempty"`
+	Resolution string     `bson:"resolution,omitempty"`
+	ResolvedAt *time.Time `bson:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	UpdatedAt  time.Time  `bson:"updated_at"`
+}
+
+func NewMongoModerationAppealService(ctx context.Context, mongoURI, dbName string) (*MongoModerationAppealService, error) {
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	appeals := db.Collection("moderation_appeals")
+
+	// Best-effort indexes.
+	_, _ = appeals.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "decision_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+	})
+
+	log.Printf("MongoDB connected (moderation appeals): db=%s", dbName)
+	return &MongoModerationAppealService{
+		client:     client,
+		db:         db,
+		appealsCol: appeals,
+	}, nil
+}
+
+func (s *MongoModerationAppealService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func moderationAppealDocToModel(d mongoModerationAppealDoc) *models.ModerationAppeal {
+	return &models.ModerationAppeal{
+		ID:         d.ID,
+		DecisionID: d.DecisionID,
+		UserID:     d.UserID,
+		TargetType: d.TargetType,
+		TargetID:   d.TargetID,
+		UploadType: d.UploadType,
+		Message:    d.Message,
+		Status:     d.Status,
+		ReviewerID: d.ReviewerID,
+		Resolution: d.Resolution,
+		ResolvedAt: d.ResolvedAt,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
+
+func (s *MongoModerationAppealService) Create(appeal *models.ModerationAppeal) (*models.ModerationAppeal, error) {
+	if appeal == nil || appeal.DecisionID == "" || appeal.UserID == "" {
+		return nil, ErrModerationBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	doc := mongoModerationAppealDoc{
+		ID:         uuid.New().String(),
+		DecisionID: appeal.DecisionID,
+		UserID:     appeal.UserID,
+		TargetType: appeal.TargetType,
+		TargetID:   appeal.TargetID,
+		UploadType: appeal.UploadType,
+		Message:    appeal.Message,
+		Status:     models.ModerationAppealPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if _, err := s.appealsCol.InsertOne(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrAppealExists
+		}
+		return nil, err
+	}
+	return moderationAppealDocToModel(doc), nil
+}
+
+func (s *MongoModerationAppealService) Get(appealID string) (*models.ModerationAppeal, error) {
+	if appealID == "" {
+		return nil, ErrAppealNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var d mongoModerationAppealDoc
+	if err := s.appealsCol.FindOne(ctx, bson.M{"_id": appealID}).Decode(&d); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrAppealNotFound
+		}
+		return nil, err
+	}
+	return moderationAppealDocToModel(d), nil
+}
+
+func (s *MongoModerationAppealService) ListByUser(userID string, limit int) ([]*models.ModerationAppeal, error) {
+	if userID == "" {
+		return nil, ErrModerationBadInput
+	}
+	return s.find(bson.M{"user_id": userID}, -1, limit)
+}
+
+func (s *MongoModerationAppealService) List(status string, limit int) ([]*models.ModerationAppeal, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	return s.find(filter, 1, limit)
+}
+
+func (s *MongoModerationAppealService) find(filter bson.M, order, limit int) ([]*models.ModerationAppeal, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := s.appealsCol.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: order}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.ModerationAppeal, 0)
+	for cur.Next(ctx) {
+		var d mongoModerationAppealDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, moderationAppealDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *MongoModerationAppealService) Resolve(appealID, reviewerID, status, resolution string) (*models.ModerationAppeal, error) {
+	if appealID == "" || reviewerID == "" ||
+		(status != models.ModerationAppealUpheld && status != models.ModerationAppealOverturned) {
+		return nil, ErrModerationBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	var d mongoModerationAppealDoc
+	err := s.appealsCol.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": appealID, "status": models.ModerationAppealPending},
+		bson.M{"$set": bson.M{
+			"status":      status,
+			"reviewer_id": reviewerID,
+			"resolution":  resolution,
+			"resolved_at": now,
+			"updated_at":  now,
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&d)
+	if err == nil {
+		return moderationAppealDocToModel(d), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	// Nothing matched: either it doesn't exist or it was already resolved.
+	if _, err := s.Get(appealID); err != nil {
+		return nil, err
+	}
+	return nil, ErrAppealResolved
+}
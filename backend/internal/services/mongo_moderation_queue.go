@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoModerationQueue struct {
+	client   *mongo.Client
+	db       *mongo.Database
+	queueCol *mongo.Collection
+}
+
+// mongoModerationQueueDoc keeps open_key ("type:id") only while the entry is open;
+// a unique sparse index on it allows one open entry per target.
+type mongoModerationQueueDoc struct {
+	ID             string     `bson:"_id"`
+	TargetType     string     `bson:"target_type"`
+	TargetID       string     `bson:"target_id"`
+	UploadType     string     `bson:"upload_type,omitempty"`
+	UserID         string     `bson:"user_id"`
+	Source         string     `bson:"source"`
+	Rule           string     `bson:"rule,omitempty"`
+	Reason         string     `bson:"reason,omitempty"`
+	Status         string     `bson:"status"`
+	OpenKey        string     `bson:"open_key,omitempty"`
+	ClaimedBy      string     `bson:"claimed_by,omitempty"`
+	ClaimedAt      *time.Time `bson:"claimed_at,omitempty"`
+	ReviewerID     string     `bson:"reviewer_id,omitempty"`
+	DecisionReason string     `bson:"decision_reason,omitempty"`
+	DecisionID     string     `bson:"decision_id,omitempty"`
+	DecidedAt      *time.Time `bson:"decided_at,omitempty"`
+	CreatedAt      time.Time  `bson:"created_at"`
+	UpdatedAt      time.Time  `bson:"updated_at"`
+}
+
+func NewMongoModerationQueue(ctx context.Context, mongoURI, dbName string) (*MongoModerationQueue, error) {
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	queue := db.Collection("moderation_queue")
+
+	// Best-effort indexes.
+	_, _ = queue.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "open_key", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	})
+
+	log.Printf("MongoDB connected (moderation queue): db=%s", dbName)
+	return &MongoModerationQueue{
+		client:   client,
+		db:       db,
+		queueCol: queue,
+	}, nil
+}
+
+func (s *MongoModerationQueue) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func moderationQueueDocToModel(d mongoModerationQueueDoc) *models.ModerationQueueItem {
+	return &models.ModerationQueueItem{
+		ID:             d.ID,
+		TargetType:     d.TargetType,
+		TargetID:       d.TargetID,
+		UploadType:     d.UploadType,
+		UserID:         d.UserID,
+		Source:         d.Source,
+		Rule:           d.Rule,
+		Reason:         d.Reason,
+		Status:         d.Status,
+		ClaimedBy:      d.ClaimedBy,
+		ClaimedAt:      d.ClaimedAt,
+		ReviewerID:     d.ReviewerID,
+		DecisionReason: d.DecisionReason,
+		DecisionID:     d.DecisionID,
+		DecidedAt:      d.DecidedAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
+
+func moderationOpenKey(targetType, targetID string) string {
+	return targetType + ":" + targetID
+}
+
+func (s *MongoModerationQueue) Enqueue(item *models.ModerationQueueItem) (*models.ModerationQueueItem, error) {
+	if item == nil || !models.IsModerationTarget(item.TargetType) || item.TargetID == "" {
+		return nil, ErrModerationBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	doc := mongoModerationQueueDoc{
+		ID:         uuid.New().String(),
+		TargetType: item.TargetType,
+		TargetID:   item.TargetID,
+		UploadType: item.UploadType,
+		UserID:     item.UserID,
+		Source:     item.Source,
+		Rule:       item.Rule,
+		Reason:     item.Reason,
+		Status:     models.ModerationQueuePending,
+		OpenKey:    moderationOpenKey(item.TargetType, item.TargetID),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if _, err := s.queueCol.InsertOne(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			var existing mongoModerationQueueDoc
+			if err := s.queueCol.FindOne(ctx, bson.M{"open_key": doc.OpenKey}).Decode(&existing); err != nil {
+				return nil, err
+			}
+			return moderationQueueDocToModel(existing), nil
+		}
+		return nil, err
+	}
+	return moderationQueueDocToModel(doc), nil
+}
+
+func (s *MongoModerationQueue) Get(itemID string) (*models.ModerationQueueItem, error) {
+	if itemID == "" {
+		return nil, ErrModerationItemNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var d mongoModerationQueueDoc
+	if err := s.queueCol.FindOne(ctx, bson.M{"_id": itemID}).Decode(&d); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrModerationItemNotFound
+		}
+		return nil, err
+	}
+	return moderationQueueDocToModel(d), nil
+}
+
+func (s *MongoModerationQueue) LastDecided(targetType, targetID string) (*models.ModerationQueueItem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var d mongoModerationQueueDoc
+	err := s.queueCol.FindOne(
+		ctx,
+		bson.M{
+			"target_type": targetType,
+			"target_id":   targetID,
+			"status":      bson.M{"$in": bson.A{models.ModerationQueueApproved, models.ModerationQueueRejected}},
+		},
+		options.FindOne().SetSort(bson.D{{Key: "decided_at", Value: -1}}),
+	).Decode(&d)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrModerationItemNotFound
+		}
+		return nil, err
+	}
+	return moderationQueueDocToModel(d), nil
+}
+
+func (s *MongoModerationQueue) List(status, targetType string, limit int) ([]*models.ModerationQueueItem, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	switch status {
+	case "":
+	case ModerationQueueOpen:
+		filter["status"] = bson.M{"$in": bson.A{models.ModerationQueuePending, models.ModerationQueueClaimed}}
+	default:
+		filter["status"] = status
+	}
+	if targetType != "" {
+		filter["target_type"] = targetType
+	}
+
+	cur, err := s.queueCol.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.ModerationQueueItem, 0)
+	for cur.Next(ctx) {
+		var d mongoModerationQueueDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, moderationQueueDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *MongoModerationQueue) Claim(itemID, reviewerID string) (*models.ModerationQueueItem, error) {
+	if itemID == "" || reviewerID == "" {
+		return nil, ErrModerationBadInput
+	}
+
+	now := time.Now().UTC()
+	filter := moderationActionableFilter(itemID, reviewerID, now)
+	update := bson.M{"$set": bson.M{
+		"status":     models.ModerationQueueClaimed,
+		"claimed_by": reviewerID,
+		"claimed_at": now,
+		"updated_at": now,
+	}}
+	return s.updateOrExplain(itemID, filter, update)
+}
+
+func (s *MongoModerationQueue) Decide(itemID, reviewerID, status, reason, decisionID string) (*models.ModerationQueueItem, error) {
+	if itemID == "" || reviewerID == "" ||
+		(status != models.ModerationQueueApproved && status != models.ModerationQueueRejected) {
+		return nil, ErrModerationBadInput
+	}
+
+	now := time.Now().UTC()
+	filter := moderationActionableFilter(itemID, reviewerID, now)
+	update := bson.M{
+		"$set": bson.M{
+			"status":          status,
+			"reviewer_id":     reviewerID,
+			"decision_reason": reason,
+			"decision_id":     decisionID,
+			"decided_at":      now,
+			"updated_at":      now,
+		},
+		"$unset": bson.M{"open_key": ""},
+	}
+	return s.updateOrExplain(itemID, filter, update)
+}
+
+// moderationActionableFilter matches an entry the reviewer may claim or decide: it is
+// pending, already theirs, or another reviewer's claim has expired.
+func moderationActionableFilter(itemID, reviewerID string, now time.Time) bson.M {
+	return bson.M{
+		"_id": itemID,
+		"$or": bson.A{
+			bson.M{"status": models.ModerationQueuePending},
+			bson.M{"status": models.ModerationQueueClaimed, "claimed_by": reviewerID},
+			bson.M{"status": models.ModerationQueueClaimed, "claimed_at": bson.M{"$lte": now.Add(-models.ModerationClaimTTL)}},
+		},
+	}
+}
+
+// updateOrExplain applies a conditional update. When nothing matched it works out
+// why: the entry is missing, already decided, or claimed by someone else.
+func (s *MongoModerationQueue) updateOrExplain(itemID string, filter, update bson.M) (*models.ModerationQueueItem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var d mongoModerationQueueDoc
+	err := s.queueCol.FindOneAndUpdate(
+		ctx,
+		filter,
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&d)
+	if err == nil {
+		return moderationQueueDocToModel(d), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	var current mongoModerationQueueDoc
+	if err := s.queueCol.FindOne(ctx, bson.M{"_id": itemID}).Decode(&current); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrModerationItemNotFound
+		}
+		return nil, err
+	}
+	if !moderationQueueDocToModel(current).Open() {
+		return nil, ErrModerationItemDecided
+	}
+	return nil, ErrModerationItemClaimed
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoModerationRunService struct {
+	client  *mongo.Client
+	db      *mongo.Database
+	runsCol *mongo.Collection
+}
+
+type mongoModerationRunDoc struct {
+	ID            string            `bson:"_id"`
+	TargetType    string            `bson:"target_type"`
+	TargetID      string            `bson:"target_id"`
+	UploadType    string            `bson:"upload_type,omitempty"`
+	UserID        string            `bson:"user_id"`
+	Source        string            `bson:"source"`
+	Scores        map[string]string `bson:"scores,omitempty"`
+	PolicyVersion string            `bson:"policy_version,omitempty"`
+	Outcome       string            `bson:"outcome"`
+	Rule          string            `bson:"rule,omitempty"`
+	Error         string            `bson:"error,omitempty"`
+	ReviewerID    string            `bson:"reviewer_id,omitempty"`
+	Reason        string            `bson:"reason,omitempty"`
+	QueueItemID   string            `bson:"queue_item_id,omitempty"`
+	StartedAt     time.Time         `bson:"started_at"`
+	CompletedAt   time.Time         `bson:"completed_at"`
+}
+
+func NewMongoModerationRunService(ctx context.Context, mongoURI, dbName string) (*MongoModerationRunService, error) {
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	runs := db.Collection("moderation_runs")
+
+	// Best-effort indexes.
+	_, _ = runs.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "completed_at", Value: -1}}},
+		{Keys: bson.D{{Key: "target_id", Value: 1}}},
+		{Keys: bson.D{{Key: "outcome", Value: 1}, {Key: "completed_at", Value: -1}}},
+		{Keys: bson.D{{Key: "completed_at", Value: -1}}},
+	})
+
+	log.Printf("MongoDB connected (moderation runs): db=%s", dbName)
+	return &MongoModerationRunService{
+		client:  client,
+		db:      db,
+		runsCol: runs,
+	}, nil
+}
+
+func (s *MongoModerationRunService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func moderationRunDocToModel(d mongoModerationRunDoc) *models.ModerationRun {
+	return &models.ModerationRun{
+		ID:            d.ID,
+		TargetType:    d.TargetType,
+		TargetID:      d.TargetID,
+		UploadType:    d.UploadType,
+		UserID:        d.UserID,
+		Source:        d.Source,
+		Scores:        d.Scores,
+		PolicyVersion: d.PolicyVersion,
+		Outcome:       d.Outcome,
+		Rule:          d.Rule,
+		Error:         d.Error,
+		ReviewerID:    d.ReviewerID,
+		Reason:        d.Reason,
+		QueueItemID:   d.QueueItemID,
+		StartedAt:     d.StartedAt,
+		CompletedAt:   d.CompletedAt,
+	}
+}
+
+func (s *MongoModerationRunService) Record(run *models.ModerationRun) error {
+	if run == nil || (run.TargetID == "" && run.UserID == "") || run.Outcome == "" {
+		return ErrModerationBadInput
+	}
+	if run.ID == "" {
+		run.ID = uuid.New().String()
+	}
+	now := time.Now().UTC()
+	if run.StartedAt.IsZero() {
+		run.StartedAt = now
+	}
+	if run.CompletedAt.IsZero() {
+		run.CompletedAt = now
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := s.runsCol.ReplaceOne(ctx, bson.M{"_id": run.ID}, mongoModerationRunDoc{
+		ID:            run.ID,
+		TargetType:    run.TargetType,
+		TargetID:      run.TargetID,
+		UploadType:    run.UploadType,
+		UserID:        run.UserID,
+		Source:        run.Source,
+		Scores:        run.Scores,
+		PolicyVersion: run.PolicyVersion,
+		Outcome:       run.Outcome,
+		Rule:          run.Rule,
+		Error:         run.Error,
+		ReviewerID:    run.ReviewerID,
+		Reason:        run.Reason,
+		QueueItemID:   run.QueueItemID,
+		StartedAt:     run.StartedAt,
+		CompletedAt:   run.CompletedAt,
+	}, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (s *MongoModerationRunService) Get(runID string) (*models.ModerationRun, error) {
+	if runID == "" {
+		return nil, ErrModerationRunNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var d mongoModerationRunDoc
+	if err := s.runsCol.FindOne(ctx, bson.M{"_id": runID}).Decode(&d); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrModerationRunNotFound
+		}
+		return nil, err
+	}
+	return moderationRunDocToModel(d), nil
+}
+
+func (s *MongoModerationRunService) ListByUser(userID, outcome string, limit int) ([]*models.ModerationRun, error) {
+	if userID == "" {
+		return nil, ErrModerationBadInput
+	}
+	return s.Query(models.ModerationRunQuery{UserID: userID, Outcome: outcome, Limit: limit})
+}
+
+func (s *MongoModerationRunService) Query(q models.ModerationRunQuery) ([]*models.ModerationRun, error) {
+	limit := q.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	filter := bson.M{}
+	for field, value := range map[string]string{
+		"user_id":     q.UserID,
+		"target_id":   q.TargetID,
+		"upload_type": q.UploadType,
+		"source":      q.Source,
+		"outcome":     q.Outcome,
+		"rule":        q.Rule,
+	} {
+		if value != "" {
+			filter[field] = value
+		}
+	}
+	if !q.From.IsZero() || !q.To.IsZero() {
+		completed := bson.M{}
+		if !q.From.IsZero() {
+			completed["$gte"] = q.From
+		}
+		if !q.To.IsZero() {
+			completed["$lt"] = q.To
+		}
+		filter["completed_at"] = completed
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := s.runsCol.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "completed_at", Value: -1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.ModerationRun, 0)
+	for cur.Next(ctx) {
+		var d mongoModerationRunDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, moderationRunDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
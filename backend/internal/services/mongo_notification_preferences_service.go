@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoNotificationPreferenceService struct {
+	client   *mongo.Client
+	db       *mongo.Database
+	prefsCol *mongo.Collection
+}
+
+type mongoNotificationChannelsDoc struct {
+	Push  bool `bson:"push"`
+	Email bool `bson:"email"`
+	InApp bool `bson:"in_app"`
+}
+
+type mongoQuietHoursDoc struct {
+	Enabled bool   `bson:"enabled"`
+	Start   string `bson:"start"`
+	End     string `bson:"end"`
+}
+
+type mongoNotificationPreferencesDoc struct {
+	UserID     string                                  `bson:"_id"`
+	TimeZone   string                                  `bson:"time_zone"`
+	Types      map[string]mongoNotificationChannelsDoc `bson:"types"`
+	QuietHours mongoQuietHoursDoc                      `bson:"quiet_hours"`
+	Digest     bool                                    `bson:"digest"`
+	DigestHour int                                     `bson:"digest_hour"`
+	UpdatedAt  time.Time                               `bson:"updated_at"`
+}
+
+// NewMongoNotificationPreferenceService stores one document per user in the
+// "notification_preferences" collection, keyed by Firebase UID.
+func NewMongoNotificationPreferenceService(ctx context.Context, mongoURI, dbName string) (*MongoNotificationPreferenceService, error) {
+	if mongoURI == "" || dbName == "" {
+		return nil, ErrNotificationPreferencesBadInput
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+
+	log.Printf("MongoDB connected (notification preferences): db=%s", dbName)
+	return &MongoNotificationPreferenceService{
+		client:   client,
+		db:       db,
+		prefsCol: db.Collection("notification_preferences"),
+	}, nil
+}
+
+func (s *MongoNotificationPreferenceService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func notificationPreferencesDocToModel(d mongoNotificationPreferencesDoc) *models.NotificationPreferences {
+	types := make(map[string]models.NotificationChannels, len(d.Types))
+	for t, ch := range d.Types {
+		types[t] = models.NotificationChannels{Push: ch.Push, Email: ch.Email, InApp: ch.InApp}
+	}
+	return &models.NotificationPreferences{
+		UserID:   d.UserID,
+		TimeZone: d.TimeZone,
+		Types:    types,
+		QuietHours: models.QuietHours{
+			Enabled: d.QuietHours.Enabled,
+			Start:   d.QuietHours.Start,
+			End:     d.QuietHours.End,
+		},
+		Digest:     d.Digest,
+		DigestHour: d.DigestHour,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
+
+func (s *MongoNotificationPreferenceService) GetPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	if userID == "" {
+		return nil, ErrNotificationPreferencesBadInput
+	}
+
+	var d mongoNotificationPreferencesDoc
+	if err := s.prefsCol.FindOne(ctx, bson.M{"_id": userID}).Decode(&d); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.DefaultNotificationPreferences(userID), nil
+		}
+		return nil, err
+	}
+	return notificationPreferencesDocToModel(d), nil
+}
+
+func (s *MongoNotificationPreferenceService) UpdatePreferences(userID string, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error) {
+	if userID == "" || req == nil {
+		return nil, ErrNotificationPreferencesBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Start from the defaults so a partial first update still stores a complete document.
+	defaults := models.DefaultNotificationPreferences(userID)
+	set := bson.M{"updated_at": time.Now().UTC()}
+	setOnInsert := bson.M{}
+
+	if req.TimeZone != nil {
+		set["time_zone"] = *req.TimeZone
+	} else {
+		setOnInsert["time_zone"] = defaults.TimeZone
+	}
+	if req.QuietHours != nil {
+		set["quiet_hours"] = mongoQuietHoursDoc{
+			Enabled: req.QuietHours.Enabled,
+			Start:   req.QuietHours.Start,
+			End:     req.QuietHours.End,
+		}
+	} else {
+		setOnInsert["quiet_hours"] = mongoQuietHoursDoc{
+			Enabled: defaults.QuietHours.Enabled,
+			Start:   defaults.QuietHours.Start,
+			End:     defaults.QuietHours.End,
+		}
+	}
+	if req.Digest != nil {
+		set["digest"] = *req.Digest
+	} else {
+		setOnInsert["digest"] = defaults.Digest
+	}
+	if req.DigestHour != nil {
+		set["digest_hour"] = *req.DigestHour
+	} else {
+		setOnInsert["digest_hour"] = defaults.DigestHour
+	}
+	// Merge per-type channels field by field so unrelated types are untouched.
+	for t, ch := range req.Types {
+		set["types."+t] = mongoNotificationChannelsDoc{Push: ch.Push, Email: ch.Email, InApp: ch.InApp}
+	}
+	if len(req.Types) == 0 {
+		setOnInsert["types"] = bson.M{}
+	}
+
+	update := bson.M{"$set": set}
+	if len(setOnInsert) > 0 {
+		update["$setOnInsert"] = setOnInsert
+	}
+
+	res := s.prefsCol.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": userID},
+		update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	)
+
+	var updated mongoNotificationPreferencesDoc
+	if err := res.Decode(&updated); err != nil {
+		return nil, err
+	}
+	return notificationPreferencesDocToModel(updated), nil
+}
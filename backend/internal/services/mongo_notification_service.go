@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoNotificationService struct {
+	client           *mongo.Client
+	db               *mongo.Database
+	notificationsCol *mongo.Collection
+	holdsCol         *mongo.Collection
+	ttl              time.Duration
+}
+
+type mongoNotificationDoc struct {
+	ID        string            `bson:"_id"`
+	UserID    string            `bson:"user_id"`
+	Type      string            `bson:"type"`
+	Title     string            `bson:"title"`
+	Body      string            `bson:"body"`
+	Data      map[string]string `bson:"data,omitempty"`
+	Read      bool              `bson:"read"`
+	ReadAt    *time.Time        `bson:"read_at,omitempty"`
+	CreatedAt time.Time         `bson:"created_at"`
+	ExpiresAt time.Time         `bson:"expires_at"`
+}
+
+type mongoNotificationHoldDoc struct {
+	ID           string               `bson:"_id"`
+	UserID       string               `bson:"user_id"`
+	DeliverAfter time.Time            `bson:"deliver_after"`
+	Notification mongoNotificationDoc `bson:"notification"`
+}
+
+// NewMongoNotificationService stores the inbox in the "notifications" collection and
+// deferred pushes in "notification_holds". ttl <= 0 uses DefaultNotificationTTL.
+func NewMongoNotificationService(ctx context.Context, mongoURI, dbName string, ttl time.Duration) (*MongoNotificationService, error) {
+	if mongoURI == "" || dbName == "" {
+		return nil, ErrNotificationBadInput
+	}
+	if ttl <= 0 {
+		ttl = DefaultNotificationTTL
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	col := db.Collection("notifications")
+
+	// Best-effort indexes. Mongo's TTL monitor deletes documents once expires_at passes.
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "read", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+
+	holds := db.Collection("notification_holds")
+	_, _ = holds.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "deliver_after", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	})
+
+	log.Printf("MongoDB connected (notifications): db=%s", dbName)
+	return &MongoNotificationService{
+		client:           client,
+		db:               db,
+		notificationsCol: col,
+		holdsCol:         holds,
+		ttl:              ttl,
+	}, nil
+}
+
+func (s *MongoNotificationService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func notificationDocToModel(d mongoNotificationDoc) *models.Notification {
+	return &models.Notification{
+		ID:        d.ID,
+		UserID:    d.UserID,
+		Type:      d.Type,
+		Title:     d.Title,
+		Body:      d.Body,
+		Data:      d.Data,
+		Read:      d.Read,
+		ReadAt:    d.ReadAt,
+		CreatedAt: d.CreatedAt,
+		ExpiresAt: d.ExpiresAt,
+	}
+}
+
+func notificationModelToDoc(n *models.Notification) mongoNotificationDoc {
+	return mongoNotificationDoc{
+		ID:        n.ID,
+		UserID:    n.UserID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      n.Data,
+		Read:      n.Read,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+		ExpiresAt: n.ExpiresAt,
+	}
+}
+
+func (s *MongoNotificationService) Save(ctx context.Context, n *models.Notification) error {
+	if n == nil || n.UserID == "" {
+		return ErrNotificationBadInput
+	}
+	if n.ID == "" {
+		n.ID = uuid.New().String()
+	}
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now().UTC()
+	}
+	if n.ExpiresAt.IsZero() {
+		n.ExpiresAt = n.CreatedAt.Add(s.ttl)
+	}
+
+	_, err := s.notificationsCol.InsertOne(ctx, notificationModelToDoc(n))
+	if mongo.IsDuplicateKeyError(err) {
+		// Same notification saved twice (e.g. a retried publish); keep the first.
+		return nil
+	}
+	return err
+}
+
+func (s *MongoNotificationService) List(userID string, before time.Time, limit int) (*models.NotificationPage, error) {
+	if userID == "" {
+		return nil, ErrNotificationBadInput
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Expired entries may linger until the TTL monitor runs; hide them explicitly.
+	filter := bson.M{"user_id": userID, "expires_at": bson.M{"$gt": time.Now().UTC()}}
+	if !before.IsZero() {
+		filter["created_at"] = bson.M{"$lt": before}
+	}
+
+	// Fetch one extra to know whether another page exists.
+	cur, err := s.notificationsCol.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit+1)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	list := make([]*models.Notification, 0, limit)
+	for cur.Next(ctx) {
+		var d mongoNotificationDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		list = append(list, notificationDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &models.NotificationPage{Notifications: list}
+	if len(list) > limit {
+		page.Notifications = list[:limit]
+		page.NextCursor = list[limit-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	unread, err := s.unreadCount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	page.UnreadCount = unread
+	return page, nil
+}
+
+func (s *MongoNotificationService) UnreadCount(userID string) (int, error) {
+	if userID == "" {
+		return 0, ErrNotificationBadInput
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return s.unreadCount(ctx, userID)
+}
+
+func (s *MongoNotificationService) unreadCount(ctx context.Context, userID string) (int, error) {
+	n, err := s.notificationsCol.CountDocuments(ctx, bson.M{
+		"user_id":    userID,
+		"read":       false,
+		"expires_at": bson.M{"$gt": time.Now().UTC()},
+	})
+	return int(n), err
+}
+
+func (s *MongoNotificationService) MarkRead(userID, notificationID string) (*models.Notification, error) {
+	if userID == "" || notificationID == "" {
+		return nil, ErrNotificationBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	res := s.notificationsCol.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": notificationID, "user_id": userID},
+		bson.M{"$set": bson.M{"read": true, "read_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+
+	var updated mongoNotificationDoc
+	if err := res.Decode(&updated); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	return notificationDocToModel(updated), nil
+}
+
+func (s *MongoNotificationService) MarkAllRead(userID string) (int, error) {
+	if userID == "" {
+		return 0, ErrNotificationBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := s.notificationsCol.UpdateMany(
+		ctx,
+		bson.M{"user_id": userID, "read": false},
+		bson.M{"$set": bson.M{"read": true, "read_at": time.Now().UTC()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return int(res.ModifiedCount), nil
+}
+
+func (s *MongoNotificationService) Hold(ctx context.Context, n *models.Notification, deliverAfter time.Time) error {
+	if n == nil || n.UserID == "" {
+		return ErrNotificationBadInput
+	}
+	if n.ID == "" {
+		n.ID = uuid.New().String()
+	}
+
+	doc := mongoNotificationHoldDoc{
+		ID:           n.ID,
+		UserID:       n.UserID,
+		DeliverAfter: deliverAfter.UTC(),
+		Notification: notificationModelToDoc(n),
+	}
+	_, err := s.holdsCol.InsertOne(ctx, doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *MongoNotificationService) TakeDue(ctx context.Context, now time.Time, limit int) ([]*models.Notification, error) {
+	if limit <= 0 {
+		limit = 500
+	}
+
+	// FindOneAndDelete claims each hold atomically, so several server instances can
+	// drain the queue without delivering anything twice.
+	out := make([]*models.Notification, 0)
+	for len(out) < limit {
+		var d mongoNotificationHoldDoc
+		err := s.holdsCol.FindOneAndDelete(
+			ctx,
+			bson.M{"deliver_after": bson.M{"$lte": now.UTC()}},
+			options.FindOneAndDelete().SetSort(bson.D{{Key: "deliver_after", Value: 1}}),
+		).Decode(&d)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, notificationDocToModel(d.Notification))
+	}
+	return out, nil
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+// processedEventRetention outlasts Eventarc's retry window, after which a record is
+// only history.
+const processedEventRetention = 7 * 24 * time.Hour
+
+type MongoProcessedEventStore struct {
+	client    *mongo.Client
+	db        *mongo.Database
+	eventsCol *mongo.Collection
+}
+
+type mongoProcessedEventDoc struct {
+	ID          string     `bson:"_id"`
+	Bucket      string     `bson:"bucket"`
+	Name        string     `bson:"name"`
+	Generation  string     `bson:"generation"`
+	Status      string     `bson:"status"`
+	Attempts    int        `bson:"attempts"`
+	DecisionID  string     `bson:"decision_id,omitempty"`
+	Outcome     string     `bson:"outcome,omitempty"`
+	Rule        string     `bson:"rule,omitempty"`
+	LeaseID     string     `bson:"lease_id"`
+	LeaseUntil  time.Time  `bson:"lease_until"`
+	CreatedAt   time.Time  `bson:"created_at"`
+	UpdatedAt   time.Time  `bson:"updated_at"`
+	CompletedAt *time.Time `bson:"completed_at,omitempty"`
+	ExpiresAt   time.Time  `bson:"expires_at"`
+}
+
+func NewMongoProcessedEventStore(ctx context.Context, mongoURI, dbName string) (*MongoProcessedEventStore, error) {
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	events := db.Collection("processed_events")
+
+	// Best-effort indexes.
+	_, _ = events.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		{Keys: bson.D{{Key: "bucket", Value: 1}, {Key: "name", Value: 1}}},
+	})
+
+	log.Printf("MongoDB connected (processed events): db=%s", dbName)
+	return &MongoProcessedEventStore{
+		client:    client,
+		db:        db,
+		eventsCol: events,
+	}, nil
+}
+
+func (s *MongoProcessedEventStore) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func processedEventDocToModel(d mongoProcessedEventDoc) *models.ProcessedEvent {
+	return &models.ProcessedEvent{
+		Key:         d.ID,
+		Bucket:      d.Bucket,
+		Name:        d.Name,
+		Generation:  d.Generation,
+		Status:      d.Status,
+		Attempts:    d.Attempts,
+		DecisionID:  d.DecisionID,
+		Outcome:     d.Outcome,
+		Rule:        d.Rule,
+		LeaseID:     d.LeaseID,
+		LeaseUntil:  d.LeaseUntil,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		CompletedAt: d.CompletedAt,
+	}
+}
+
+func (s *MongoProcessedEventStore) Begin(bucket, name, generation string, lease time.Duration) (*models.ProcessedEvent, error) {
+	if bucket == "" || name == "" {
+		return nil, ErrModerationBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	key := ProcessedEventKey(bucket, name, generation)
+	now := time.Now().UTC()
+
+	// Claim the event if it's new, or unfinished with an expired lease. A done or
+	// leased record doesn't match, so the upsert collides with it on _id.
+	var d mongoProcessedEventDoc
+	err := s.eventsCol.FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"_id":         key,
+			"status":      models.ProcessedEventProcessing,
+			"lease_until": bson.M{"$lte": now},
+		},
+		bson.M{
+			"$set": bson.M{
+				"lease_id":    uuid.New().String(),
+				"lease_until": now.Add(lease),
+				"updated_at":  now,
+			},
+			"$inc": bson.M{"attempts": 1},
+			"$setOnInsert": bson.M{
+				"bucket":     bucket,
+				"name":       name,
+				"generation": generation,
+				"created_at": now,
+				"expires_at": now.Add(processedEventRetention),
+			},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&d)
+	if err == nil {
+		return processedEventDocToModel(d), nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, err
+	}
+
+	if err := s.eventsCol.FindOne(ctx, bson.M{"_id": key}).Decode(&d); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrProcessedEventNotFound
+		}
+		return nil, err
+	}
+	if d.Status == models.ProcessedEventDone {
+		return processedEventDocToModel(d), nil
+	}
+	return nil, ErrEventInProgress
+}
+
+func (s *MongoProcessedEventStore) SaveDecision(key, leaseID, decisionID, outcome, rule string) error {
+	return s.update(key, leaseID, bson.M{
+		"decision_id": decisionID,
+		"outcome":     outcome,
+		"rule":        rule,
+	})
+}
+
+func (s *MongoProcessedEventStore) Complete(key, leaseID string) error {
+	now := time.Now().UTC()
+	return s.update(key, leaseID, bson.M{
+		"status":       models.ProcessedEventDone,
+		"completed_at": now,
+	})
+}
+
+func (s *MongoProcessedEventStore) Release(key, leaseID string) error {
+	return s.update(key, leaseID, bson.M{"lease_until": time.Now().UTC()})
+}
+
+// update applies set while leaseID holds the event's claim.
+func (s *MongoProcessedEventStore) update(key, leaseID string, set bson.M) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	set["updated_at"] = time.Now().UTC()
+	res, err := s.eventsCol.UpdateOne(
+		ctx,
+		bson.M{"_id": key, "lease_id": leaseID, "status": models.ProcessedEventProcessing},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		n, err := s.eventsCol.CountDocuments(ctx, bson.M{"_id": key})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrProcessedEventNotFound
+		}
+		return ErrEventLeaseLost
+	}
+	return nil
+}
@@ -199,6 +199,28 @@ func (s *MongoProfileService) RejectPendingProfilePhoto(ctx context.Context, pen
 	return err
 }
 
+// SetHidden hides or restores a profile for moderation. Missing profiles are created
+// so a report against a user who never saved a profile still takes effect.
+func (s *MongoProfileService) SetHidden(ctx context.Context, userID string, hidden bool) error {
+	if userID == "" {
+		return nil
+	}
+	now := time.Now()
+	update := bson.M{
+		"$set":         bson.M{"updated_at": now},
+		"$unset":       bson.M{"hidden": ""},
+		"$setOnInsert": bson.M{"user_id": userID, "dob": defaultDOB},
+	}
+	if hidden {
+		update = bson.M{
+			"$set":         bson.M{"hidden": true, "updated_at": now},
+			"$setOnInsert": bson.M{"user_id": userID, "dob": defaultDOB},
+		}
+	}
+	_, err := s.profilesCol.UpdateOne(ctx, bson.M{"user_id": userID}, update, options.Update().SetUpsert(hidden))
+	return err
+}
+
 // ClearPhotoIfMatches clears photo_url if it matches the provided URL.
 func (s *MongoProfileService) ClearPhotoIfMatches(ctx context.Context, userID string, url string) error {
 	if userID == "" || url == "" {
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoReportService struct {
+	client     *mongo.Client
+	db         *mongo.Database
+	reportsCol *mongo.Collection
+}
+
+type mongoReportDoc struct {
+	ID         string    `bson:"_id"`
+	ReporterID string    `bson:"reporter_id"`
+	TargetType string    `bson:"target_type"`
+	TargetID   string    `bson:"target_id"`
+	OwnerID    string    `bson:"owner_id"`
+	Reason     string    `bson:"reason"`
+	Note       string    `bson:"note,omitempty"`
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+func NewMongoReportService(ctx context.Context, mongoURI, dbName string) (*MongoReportService, error) {
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	reports := db.Collection("reports")
+
+	// Best-effort indexes.
+	_, _ = reports.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}, {Key: "reporter_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+
+	log.Printf("MongoDB connected (reports): db=%s", dbName)
+	return &MongoReportService{
+		client:     client,
+		db:         db,
+		reportsCol: reports,
+	}, nil
+}
+
+func (s *MongoReportService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func reportDocToModel(d mongoReportDoc) *models.Report {
+	return &models.Report{
+		ID:         d.ID,
+		ReporterID: d.ReporterID,
+		TargetType: d.TargetType,
+		TargetID:   d.TargetID,
+		OwnerID:    d.OwnerID,
+		Reason:     d.Reason,
+		Note:       d.Note,
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+func (s *MongoReportService) Create(report *models.Report) (*models.Report, error) {
+	if report == nil || report.ReporterID == "" || report.TargetID == "" {
+		return nil, ErrModerationBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	doc := mongoReportDoc{
+		ID:         uuid.New().String(),
+		ReporterID: report.ReporterID,
+		TargetType: report.TargetType,
+		TargetID:   report.TargetID,
+		OwnerID:    report.OwnerID,
+		Reason:     report.Reason,
+		Note:       report.Note,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if _, err := s.reportsCol.InsertOne(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrReportExists
+		}
+		return nil, err
+	}
+	return reportDocToModel(doc), nil
+}
+
+func (s *MongoReportService) CountByTarget(targetType, targetID string, since time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"target_type": targetType, "target_id": targetID}
+	if !since.IsZero() {
+		filter["created_at"] = bson.M{"$gt": since}
+	}
+	n, err := s.reportsCol.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+func (s *MongoReportService) ListByTarget(targetType, targetID string, limit int) ([]*models.Report, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := s.reportsCol.Find(
+		ctx,
+		bson.M{"target_type": targetType, "target_id": targetID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.Report, 0)
+	for cur.Next(ctx) {
+		var d mongoReportDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, reportDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoReviewService struct {
+	client       *mongo.Client
+	db           *mongo.Database
+	reviewsCol   *mongo.Collection
+	salesService SalesService
+}
+
+type mongoReviewReplyDoc struct {
+	Body      string    `bson:"body"`
+	CreatedAt time.Time `bson:"created_at"`
+	Hidden    bool      `bson:"hidden,omitempty"`
+}
+
+type mongoReviewDoc struct {
+	ID        string               `bson:"_id"`
+	SaleID    string               `bson:"sale_id"`
+	SellerID  string               `bson:"seller_id"`
+	UserID    string               `bson:"user_id"`
+	Rating    int                  `bson:"rating"`
+	Comment   string               `bson:"comment"`
+	Reply     *mongoReviewReplyDoc `bson:"reply,omitempty"`
+	CreatedAt time.Time            `bson:"created_at"`
+	Hidden    bool                 `bson:"hidden,omitempty"`
+}
+
+func NewMongoReviewService(
+	ctx context.Context,
+	mongoURI string,
+	dbName string,
+	salesService SalesService,
+) (*MongoReviewService, error) {
+	if mongoURI == "" || dbName == "" {
+		return nil, ErrReviewBadInput
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	reviews := db.Collection("reviews")
+
+	svc := &MongoReviewService{
+		client:       client,
+		db:           db,
+		reviewsCol:   reviews,
+		salesService: salesService,
+	}
+
+	// Best-effort indexes. The unique index enforces one review per user per sale.
+	_, _ = reviews.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "sale_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "seller_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+
+	log.Printf("MongoDB connected (reviews): db=%s", dbName)
+	return svc, nil
+}
+
+func (s *MongoReviewService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func reviewDocToModel(d mongoReviewDoc) *models.Review {
+	m := &models.Review{
+		ID:        d.ID,
+		SaleID:    d.SaleID,
+		SellerID:  d.SellerID,
+		UserID:    d.UserID,
+		Rating:    d.Rating,
+		Comment:   d.Comment,
+		CreatedAt: d.CreatedAt,
+		Hidden:    d.Hidden,
+	}
+	if d.Reply != nil {
+		m.Reply = &models.ReviewReply{Body: d.Reply.Body, CreatedAt: d.Reply.CreatedAt, Hidden: d.Reply.Hidden}
+	}
+	return m
+}
+
+func (s *MongoReviewService) CreateReview(userID, saleID string, req *models.CreateReviewRequest) (*models.Review, error) {
+	if userID == "" || saleID == "" {
+		return nil, ErrReviewBadInput
+	}
+
+	now := time.Now().UTC()
+	sale, err := s.reviewableSale(userID, saleID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	doc := mongoReviewDoc{
+		ID:        uuid.New().String(),
+		SaleID:    saleID,
+		SellerID:  sale.UserID,
+		UserID:    userID,
+		Rating:    req.Rating,
+		Comment:   strings.TrimSpace(req.Comment),
+		CreatedAt: now,
+	}
+	if _, err := s.reviewsCol.InsertOne(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrAlreadyReviewed
+		}
+		return nil, err
+	}
+
+	return reviewDocToModel(doc), nil
+}
+
+// reviewableSale loads the sale and checks that userID may review it at now.
+func (s *MongoReviewService) reviewableSale(userID, saleID string, now time.Time) (*models.GarageSale, error) {
+	sale, err := s.salesService.GetByID(saleID)
+	if err != nil {
+		if err == ErrSaleNotFound {
+			return nil, ErrReviewSaleNotFound
+		}
+		return nil, err
+	}
+	if sale.UserID == userID {
+		return nil, ErrReviewOwnSale
+	}
+	if !saleOpenForReview(sale, now) {
+		return nil, ErrReviewTooEarly
+	}
+	return sale, nil
+}
+
+func (s *MongoReviewService) CanReview(userID, saleID string) error {
+	if userID == "" || saleID == "" {
+		return ErrReviewBadInput
+	}
+	if _, err := s.reviewableSale(userID, saleID, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	n, err := s.reviewsCol.CountDocuments(ctx, bson.M{"sale_id": saleID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrAlreadyReviewed
+	}
+	return nil
+}
+
+func (s *MongoReviewService) ListSaleReviews(saleID string) ([]*models.Review, error) {
+	if saleID == "" {
+		return nil, ErrReviewBadInput
+	}
+	return s.find(bson.M{"sale_id": saleID, "hidden": bson.M{"$ne": true}}, 500)
+}
+
+func (s *MongoReviewService) ListSellerReviews(sellerID string, limit int) ([]*models.Review, error) {
+	if sellerID == "" {
+		return nil, ErrReviewBadInput
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 500
+	}
+	return s.find(bson.M{"seller_id": sellerID, "hidden": bson.M{"$ne": true}}, limit)
+}
+
+func (s *MongoReviewService) find(filter bson.M, limit int) ([]*models.Review, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := s.reviewsCol.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.Review, 0)
+	for cur.Next(ctx) {
+		var d mongoReviewDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		m := reviewDocToModel(d)
+		if m.Reply != nil && m.Reply.Hidden {
+			m.Reply = nil
+		}
+		out = append(out, m)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *MongoReviewService) ReplyToReview(userID, saleID, reviewID string, req *models.ReplyReviewRequest) (*models.Review, error) {
+	if userID == "" || saleID == "" || reviewID == "" {
+		return nil, ErrReviewBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	reply := mongoReviewReplyDoc{
+		Body:      strings.TrimSpace(req.Body),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	res := s.reviewsCol.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": reviewID, "sale_id": saleID, "seller_id": userID},
+		bson.M{"$set": bson.M{"reply": reply}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+
+	var updated mongoReviewDoc
+	if err := res.Decode(&updated); err != nil {
+		if err == mongo.ErrNoDocuments {
+			// Distinguish not found vs not the seller.
+			var exists mongoReviewDoc
+			if err2 := s.reviewsCol.FindOne(ctx, bson.M{"_id": reviewID, "sale_id": saleID}).Decode(&exists); err2 == mongo.ErrNoDocuments {
+				return nil, ErrReviewNotFound
+			}
+			return nil, ErrReviewReplyDenied
+		}
+		return nil, err
+	}
+
+	return reviewDocToModel(updated), nil
+}
+
+func (s *MongoReviewService) CanReply(userID, saleID, reviewID string) error {
+	if userID == "" || saleID == "" || reviewID == "" {
+		return ErrReviewBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var doc mongoReviewDoc
+	if err := s.reviewsCol.FindOne(ctx, bson.M{"_id": reviewID, "sale_id": saleID}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return ErrReviewNotFound
+		}
+		return err
+	}
+	if doc.SellerID != userID {
+		return ErrReviewReplyDenied
+	}
+	return nil
+}
+
+func (s *MongoReviewService) GetSellerRating(sellerID string) (*models.SellerRating, error) {
+	if sellerID == "" {
+		return nil, ErrReviewBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := s.reviewsCol.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"seller_id": sellerID, "hidden": bson.M{"$ne": true}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     nil,
+			"average": bson.M{"$avg": "$rating"},
+			"count":   bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := &models.SellerRating{}
+	if cur.Next(ctx) {
+		var agg struct {
+			Average float64 `bson:"average"`
+			Count   int     `bson:"count"`
+		}
+		if err := cur.Decode(&agg); err != nil {
+			return nil, err
+		}
+		// One decimal place is plenty for display.
+		out.Average = math.Round(agg.Average*10) / 10
+		out.Count = agg.Count
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *MongoReviewService) SetHidden(reviewID string, hidden bool) error {
+	return s.setHidden(bson.M{"_id": reviewID}, "hidden", hidden)
+}
+
+func (s *MongoReviewService) SetReplyHidden(reviewID string, hidden bool) error {
+	return s.setHidden(bson.M{"_id": reviewID, "reply": bson.M{"$exists": true}}, "reply.hidden", hidden)
+}
+
+func (s *MongoReviewService) setHidden(filter bson.M, field string, hidden bool) error {
+	if filter["_id"] == "" {
+		return ErrReviewBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := s.reviewsCol.UpdateOne(ctx, filter, bson.M{"$set": bson.M{field: hidden}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrReviewNotFound
+	}
+	return nil
+}
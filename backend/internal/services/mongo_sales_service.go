@@ -29,19 +29,29 @@ type mongoGeoPoint struct {
 }
 
 type mongoSaleDoc struct {
-	ID             string        `bson:"_id"`
-	UserID         string        `bson:"user_id"`
-	Title          string        `bson:"title"`
-	Description    string        `bson:"description"`
-	Address        string        `bson:"address"`
-	SaleCoverPhoto string        `bson:"sale_cover_photo,omitempty"`
-	Latitude       float64       `bson:"latitude"`
-	Longitude      float64       `bson:"longitude"`
-	StartDate      time.Time     `bson:"start_date"`
-	EndDate        time.Time     `bson:"end_date"`
-	IsActive       bool          `bson:"is_active"`
-	CreatedAt      time.Time     `bson:"created_at"`
-	Location       mongoGeoPoint `bson:"location"`
+	ID             string           `bson:"_id"`
+	UserID         string           `bson:"user_id"`
+	Title          string           `bson:"title"`
+	Description    string           `bson:"description"`
+	Address        string           `bson:"address"`
+	SaleCoverPhoto string           `bson:"sale_cover_photo,omitempty"`
+	Latitude       float64          `bson:"latitude"`
+	Longitude      float64          `bson:"longitude"`
+	StartDate      time.Time        `bson:"start_date"`
+	EndDate        time.Time        `bson:"end_date"`
+	IsActive       bool             `bson:"is_active"`
+	CoHosts        []mongoCoHostDoc `bson:"co_hosts,omitempty"`
+	Hidden         bool             `bson:"hidden,omitempty"`
+	CreatedAt      time.Time        `bson:"created_at"`
+	Location       mongoGeoPoint    `bson:"location"`
+}
+
+type mongoCoHostDoc struct {
+	UserID     string     `bson:"user_id"`
+	Role       string     `bson:"role"`
+	Status     string     `bson:"status"`
+	InvitedAt  time.Time  `bson:"invited_at"`
+	AcceptedAt *time.Time `bson:"accepted_at,omitempty"`
 }
 
 type mongoItemDoc struct {
@@ -53,6 +63,7 @@ type mongoItemDoc struct {
 	ImageURLs      []string  `bson:"image_urls,omitempty"`
 	LegacyImageURL string    `bson:"image_url,omitempty"`
 	Category       string    `bson:"category"`
+	Hidden         bool      `bson:"hidden,omitempty"`
 	CreatedAt      time.Time `bson:"created_at"`
 }
 
@@ -87,6 +98,7 @@ func NewMongoSalesService(ctx context.Context, mongoURI, dbName string) (*MongoS
 	_, _ = sales.Indexes().CreateMany(ctx, []mongo.IndexModel{
 		{Keys: bson.D{{Key: "created_at", Value: -1}}},
 		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "co_hosts.user_id", Value: 1}}},
 		{Keys: bson.D{{Key: "latitude", Value: 1}, {Key: "longitude", Value: 1}}},
 		{Keys: bson.D{{Key: "location", Value: "2dsphere"}}},
 		{Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}, {Key: "address", Value: "text"}}},
@@ -118,10 +130,57 @@ func saleDocToModel(d mongoSaleDoc) *models.GarageSale {
 		EndDate:        d.EndDate,
 		IsActive:       d.IsActive,
 		Items:          []models.Item{},
+		CoHosts:        coHostDocsToModels(d.CoHosts),
+		Hidden:         d.Hidden,
 		CreatedAt:      d.CreatedAt,
 	}
 }
 
+func coHostDocsToModels(docs []mongoCoHostDoc) []models.SaleCoHost {
+	if len(docs) == 0 {
+		return nil
+	}
+	out := make([]models.SaleCoHost, 0, len(docs))
+	for _, d := range docs {
+		out = append(out, models.SaleCoHost{
+			UserID:     d.UserID,
+			Role:       d.Role,
+			Status:     d.Status,
+			InvitedAt:  d.InvitedAt,
+			AcceptedAt: d.AcceptedAt,
+		})
+	}
+	return out
+}
+
+func coHostModelsToDocs(coHosts []models.SaleCoHost) []mongoCoHostDoc {
+	out := make([]mongoCoHostDoc, 0, len(coHosts))
+	for _, c := range coHosts {
+		out = append(out, mongoCoHostDoc{
+			UserID:     c.UserID,
+			Role:       c.Role,
+			Status:     c.Status,
+			InvitedAt:  c.InvitedAt,
+			AcceptedAt: c.AcceptedAt,
+		})
+	}
+	return out
+}
+
+// saleAccessFilter matches the sale only if userID owns it or is an accepted co-host
+// whose role allows the action.
+func saleAccessFilter(saleID, userID string, action models.SaleAction) bson.M {
+	or := []bson.M{{"user_id": userID}}
+	if roles := models.CoHostRolesFor(action); len(roles) > 0 {
+		or = append(or, bson.M{"co_hosts": bson.M{"$elemMatch": bson.M{
+			"user_id": userID,
+			"status":  models.CoHostStatusAccepted,
+			"role":    bson.M{"$in": roles},
+		}}})
+	}
+	return bson.M{"_id": saleID, "$or": or}
+}
+
 func itemDocToModel(d mongoItemDoc) *models.Item {
 	imgs := d.ImageURLs
 	if len(imgs) == 0 && d.LegacyImageURL != "" {
@@ -135,6 +194,7 @@ func itemDocToModel(d mongoItemDoc) *models.Item {
 		Price:       d.Price,
 		ImageURLs:   imgs,
 		Category:    d.Category,
+		Hidden:      d.Hidden,
 		CreatedAt:   d.CreatedAt,
 	}
 }
@@ -184,7 +244,7 @@ func (s *MongoSalesService) GetByID(id string) (*models.GarageSale, error) {
 		return nil, err
 	}
 
-	items, err := s.getItemsForSales(ctx, []string{id})
+	items, err := s.getItemsForSales(ctx, []string{id}, true)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +278,7 @@ func (s *MongoSalesService) Update(userID, saleID string, req *models.UpdateSale
 
 	res := s.salesColl.FindOneAndUpdate(
 		ctx,
-		bson.M{"_id": saleID, "user_id": userID},
+		saleAccessFilter(saleID, userID, models.SaleActionEdit),
 		update,
 		options.FindOneAndUpdate().SetReturnDocument(options.After),
 	)
@@ -236,7 +296,7 @@ func (s *MongoSalesService) Update(userID, saleID string, req *models.UpdateSale
 		return nil, err
 	}
 
-	items, err := s.getItemsForSales(ctx, []string{saleID})
+	items, err := s.getItemsForSales(ctx, []string{saleID}, true)
 	if err != nil {
 		return nil, err
 	}
@@ -260,7 +320,7 @@ func (s *MongoSalesService) SetSaleCoverPhoto(userID, saleID, coverURL string) (
 
 	res := s.salesColl.FindOneAndUpdate(
 		ctx,
-		bson.M{"_id": saleID, "user_id": userID},
+		saleAccessFilter(saleID, userID, models.SaleActionEdit),
 		update,
 		options.FindOneAndUpdate().SetReturnDocument(options.After),
 	)
@@ -278,7 +338,7 @@ func (s *MongoSalesService) SetSaleCoverPhoto(userID, saleID, coverURL string) (
 		return nil, err
 	}
 
-	items, err := s.getItemsForSales(ctx, []string{saleID})
+	items, err := s.getItemsForSales(ctx, []string{saleID}, true)
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +353,7 @@ func (s *MongoSalesService) Delete(userID, saleID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Ensure ownership.
+	// Only the owner may delete.
 	var sale mongoSaleDoc
 	if err := s.salesColl.FindOne(ctx, bson.M{"_id": saleID}).Decode(&sale); err != nil {
 		if err == mongo.ErrNoDocuments {
@@ -301,7 +361,7 @@ func (s *MongoSalesService) Delete(userID, saleID string) error {
 		}
 		return err
 	}
-	if sale.UserID != userID {
+	if !saleDocToModel(sale).CanUser(userID, models.SaleActionManage) {
 		return ErrUnauthorized
 	}
 
@@ -328,7 +388,7 @@ func (s *MongoSalesService) setActive(userID, saleID string, active bool) (*mode
 
 	res := s.salesColl.FindOneAndUpdate(
 		ctx,
-		bson.M{"_id": saleID, "user_id": userID},
+		saleAccessFilter(saleID, userID, models.SaleActionRun),
 		bson.M{"$set": bson.M{"is_active": active}},
 		options.FindOneAndUpdate().SetReturnDocument(options.After),
 	)
@@ -345,7 +405,7 @@ func (s *MongoSalesService) setActive(userID, saleID string, active bool) (*mode
 		return nil, err
 	}
 
-	items, err := s.getItemsForSales(ctx, []string{saleID})
+	items, err := s.getItemsForSales(ctx, []string{saleID}, true)
 	if err != nil {
 		return nil, err
 	}
@@ -370,6 +430,7 @@ func (s *MongoSalesService) ListByBounds(minLat, maxLat, minLng, maxLng float64,
 	filter := bson.M{
 		"latitude":  bson.M{"$gte": minLat, "$lte": maxLat},
 		"longitude": bson.M{"$gte": minLng, "$lte": maxLng},
+		"hidden":    bson.M{"$ne": true},
 	}
 
 	cur, err := s.salesColl.Find(
@@ -401,7 +462,7 @@ func (s *MongoSalesService) ListByBounds(minLat, maxLat, minLng, maxLng float64,
 		return results, nil
 	}
 
-	itemsBySale, err := s.getItemsForSales(ctx, saleIDs)
+	itemsBySale, err := s.getItemsForSales(ctx, saleIDs, false)
 	if err != nil {
 		return nil, err
 	}
@@ -458,7 +519,67 @@ func (s *MongoSalesService) ListByUser(userID string, limit int) ([]*models.Gara
 		return results, nil
 	}
 
-	itemsBySale, err := s.getItemsForSales(ctx, saleIDs)
+	itemsBySale, err := s.getItemsForSales(ctx, saleIDs, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range saleDocs {
+		m := saleDocToModel(d)
+		if items, ok := itemsBySale[d.ID]; ok {
+			m.Items = items
+		}
+		results = append(results, m)
+	}
+	return results, nil
+}
+
+func (s *MongoSalesService) ListUpcomingByUsers(userIDs []string, limit int) ([]*models.GarageSale, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	results := make([]*models.GarageSale, 0)
+	if len(userIDs) == 0 {
+		return results, nil
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 500
+	}
+
+	filter := bson.M{
+		"user_id":  bson.M{"$in": userIDs},
+		"end_date": bson.M{"$gte": time.Now().UTC()},
+		"hidden":   bson.M{"$ne": true},
+	}
+
+	cur, err := s.salesColl.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "start_date", Value: 1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	saleDocs := make([]mongoSaleDoc, 0)
+	saleIDs := make([]string, 0)
+	for cur.Next(ctx) {
+		var d mongoSaleDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		saleDocs = append(saleDocs, d)
+		saleIDs = append(saleIDs, d.ID)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	if len(saleDocs) == 0 {
+		return results, nil
+	}
+
+	itemsBySale, err := s.getItemsForSales(ctx, saleIDs, false)
 	if err != nil {
 		return nil, err
 	}
@@ -492,6 +613,7 @@ func (s *MongoSalesService) ListNearby(lat, lng, radiusMi float64) ([]*models.Ga
 				},
 			},
 		},
+		"hidden": bson.M{"$ne": true},
 	}
 
 	cur, err := s.salesColl.Find(
@@ -523,7 +645,7 @@ func (s *MongoSalesService) ListNearby(lat, lng, radiusMi float64) ([]*models.Ga
 		return results, nil
 	}
 
-	itemsBySale, err := s.getItemsForSales(ctx, saleIDs)
+	itemsBySale, err := s.getItemsForSales(ctx, saleIDs, false)
 	if err != nil {
 		return nil, err
 	}
@@ -574,6 +696,9 @@ func (s *MongoSalesService) SearchNearby(lat, lng, radiusMi float64, q string) (
 			bson.M{
 				"$text": bson.M{"$search": q},
 			},
+			bson.M{
+				"hidden": bson.M{"$ne": true},
+			},
 		},
 	}
 
@@ -606,7 +731,7 @@ func (s *MongoSalesService) SearchNearby(lat, lng, radiusMi float64, q string) (
 		return results, nil
 	}
 
-	itemsBySale, err := s.getItemsForSales(ctx, saleIDs)
+	itemsBySale, err := s.getItemsForSales(ctx, saleIDs, false)
 	if err != nil {
 		return nil, err
 	}
@@ -625,7 +750,7 @@ func (s *MongoSalesService) AddItem(userID, saleID string, req *models.CreateIte
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Ensure sale exists + ownership.
+	// Ensure sale exists + access.
 	var sale mongoSaleDoc
 	if err := s.salesColl.FindOne(ctx, bson.M{"_id": saleID}).Decode(&sale); err != nil {
 		if err == mongo.ErrNoDocuments {
@@ -633,7 +758,7 @@ func (s *MongoSalesService) AddItem(userID, saleID string, req *models.CreateIte
 		}
 		return nil, err
 	}
-	if sale.UserID != userID {
+	if !saleDocToModel(sale).CanUser(userID, models.SaleActionEdit) {
 		return nil, ErrUnauthorized
 	}
 
@@ -661,7 +786,7 @@ func (s *MongoSalesService) UpdateItem(userID, saleID, itemID string, req *model
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Ensure sale exists + ownership.
+	// Ensure sale exists + access.
 	var sale mongoSaleDoc
 	if err := s.salesColl.FindOne(ctx, bson.M{"_id": saleID}).Decode(&sale); err != nil {
 		if err == mongo.ErrNoDocuments {
@@ -669,7 +794,7 @@ func (s *MongoSalesService) UpdateItem(userID, saleID, itemID string, req *model
 		}
 		return nil, err
 	}
-	if sale.UserID != userID {
+	if !saleDocToModel(sale).CanUser(userID, models.SaleActionEdit) {
 		return nil, ErrUnauthorized
 	}
 
@@ -705,7 +830,7 @@ func (s *MongoSalesService) DeleteItem(userID, saleID, itemID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Ensure sale exists + ownership.
+	// Ensure sale exists + access.
 	var sale mongoSaleDoc
 	if err := s.salesColl.FindOne(ctx, bson.M{"_id": saleID}).Decode(&sale); err != nil {
 		if err == mongo.ErrNoDocuments {
@@ -713,7 +838,7 @@ func (s *MongoSalesService) DeleteItem(userID, saleID, itemID string) error {
 		}
 		return err
 	}
-	if sale.UserID != userID {
+	if !saleDocToModel(sale).CanUser(userID, models.SaleActionEdit) {
 		return ErrUnauthorized
 	}
 
@@ -727,14 +852,219 @@ func (s *MongoSalesService) DeleteItem(userID, saleID, itemID string) error {
 	return nil
 }
 
-func (s *MongoSalesService) getItemsForSales(ctx context.Context, saleIDs []string) (map[string][]models.Item, error) {
+func (s *MongoSalesService) InviteCoHost(ownerID, saleID string, req *models.InviteCoHostRequest) (*models.GarageSale, error) {
+	return s.updateCoHosts(saleID, func(sale *models.GarageSale) error {
+		return inviteCoHost(sale, ownerID, req, time.Now().UTC())
+	})
+}
+
+func (s *MongoSalesService) AcceptCoHostInvite(userID, saleID string) (*models.GarageSale, error) {
+	return s.updateCoHosts(saleID, func(sale *models.GarageSale) error {
+		return acceptCoHostInvite(sale, userID, time.Now().UTC())
+	})
+}
+
+func (s *MongoSalesService) RemoveCoHost(userID, saleID, coHostID string) (*models.GarageSale, error) {
+	return s.updateCoHosts(saleID, func(sale *models.GarageSale) error {
+		return removeCoHost(sale, userID, coHostID)
+	})
+}
+
+// updateCoHosts applies change to the sale's co-host list and saves it. The write is
+// conditioned on the list being unchanged since it was read, so concurrent edits fail
+// with ErrCoHostConflict rather than overwriting each other.
+func (s *MongoSalesService) updateCoHosts(saleID string, change func(*models.GarageSale) error) (*models.GarageSale, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var doc mongoSaleDoc
+	if err := s.salesColl.FindOne(ctx, bson.M{"_id": saleID}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrSaleNotFound
+		}
+		return nil, err
+	}
+
+	sale := saleDocToModel(doc)
+	if err := change(sale); err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": saleID, "co_hosts": doc.CoHosts}
+	if len(doc.CoHosts) == 0 {
+		filter["co_hosts"] = bson.M{"$in": bson.A{nil, bson.A{}}}
+	}
+	res, err := s.salesColl.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"co_hosts": coHostModelsToDocs(sale.CoHosts)}})
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, ErrCoHostConflict
+	}
+
+	items, err := s.getItemsForSales(ctx, []string{saleID}, true)
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := items[saleID]; ok {
+		sale.Items = list
+	}
+	return sale, nil
+}
+
+func (s *MongoSalesService) ListCoHostedByUser(userID string, limit int) ([]*models.GarageSale, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if limit <= 0 || limit > 500 {
+		limit = 500
+	}
+
+	cur, err := s.salesColl.Find(
+		ctx,
+		bson.M{"co_hosts.user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	saleDocs := make([]mongoSaleDoc, 0)
+	saleIDs := make([]string, 0)
+	for cur.Next(ctx) {
+		var d mongoSaleDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		saleDocs = append(saleDocs, d)
+		saleIDs = append(saleIDs, d.ID)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	itemsBySale, err := s.getItemsForSales(ctx, saleIDs, true)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*models.GarageSale, 0, len(saleDocs))
+	for _, d := range saleDocs {
+		m := saleDocToModel(d)
+		if list, ok := itemsBySale[d.ID]; ok {
+			m.Items = list
+		}
+		results = append(results, m)
+	}
+	return results, nil
+}
+
+func (s *MongoSalesService) SetHidden(saleID string, hidden bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$unset": bson.M{"hidden": ""}}
+	if hidden {
+		update = bson.M{"$set": bson.M{"hidden": true}}
+	}
+	res, err := s.salesColl.UpdateOne(ctx, bson.M{"_id": saleID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrSaleNotFound
+	}
+	return nil
+}
+
+func (s *MongoSalesService) SetItemHidden(saleID, itemID string, hidden bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$unset": bson.M{"hidden": ""}}
+	if hidden {
+		update = bson.M{"$set": bson.M{"hidden": true}}
+	}
+	res, err := s.itemsColl.UpdateOne(ctx, bson.M{"_id": itemID, "sale_id": saleID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+// ApprovePendingSaleCover points any sale whose cover is pendingPath at approvedURL.
+// Used by the moderation worker once the object has been promoted.
+func (s *MongoSalesService) ApprovePendingSaleCover(ctx context.Context, pendingPath string, approvedURL string) error {
+	if strings.TrimSpace(pendingPath) == "" || strings.TrimSpace(approvedURL) == "" {
+		return nil
+	}
+	_, err := s.salesColl.UpdateMany(ctx, bson.M{"sale_cover_photo": pendingPath}, bson.M{
+		"$set": bson.M{"sale_cover_photo": approvedURL},
+	})
+	return err
+}
+
+// RejectPendingSaleCover clears the cover of any sale whose cover is pendingPath.
+func (s *MongoSalesService) RejectPendingSaleCover(ctx context.Context, pendingPath string) error {
+	if strings.TrimSpace(pendingPath) == "" {
+		return nil
+	}
+	_, err := s.salesColl.UpdateMany(ctx, bson.M{"sale_cover_photo": pendingPath}, bson.M{
+		"$set": bson.M{"sale_cover_photo": ""},
+	})
+	return err
+}
+
+// ApprovePendingItemImage replaces pendingPath with approvedURL in item image lists
+// (and the legacy single image field).
+func (s *MongoSalesService) ApprovePendingItemImage(ctx context.Context, pendingPath string, approvedURL string) error {
+	if strings.TrimSpace(pendingPath) == "" || strings.TrimSpace(approvedURL) == "" {
+		return nil
+	}
+	if _, err := s.itemsColl.UpdateMany(ctx, bson.M{"image_urls": pendingPath}, bson.M{
+		"$set": bson.M{"image_urls.$": approvedURL},
+	}); err != nil {
+		return err
+	}
+	_, err := s.itemsColl.UpdateMany(ctx, bson.M{"image_url": pendingPath}, bson.M{
+		"$set": bson.M{"image_url": approvedURL},
+	})
+	return err
+}
+
+// RejectPendingItemImage removes pendingPath from item image lists.
+func (s *MongoSalesService) RejectPendingItemImage(ctx context.Context, pendingPath string) error {
+	if strings.TrimSpace(pendingPath) == "" {
+		return nil
+	}
+	if _, err := s.itemsColl.UpdateMany(ctx, bson.M{"image_urls": pendingPath}, bson.M{
+		"$pull": bson.M{"image_urls": pendingPath},
+	}); err != nil {
+		return err
+	}
+	_, err := s.itemsColl.UpdateMany(ctx, bson.M{"image_url": pendingPath}, bson.M{
+		"$set": bson.M{"image_url": ""},
+	})
+	return err
+}
+
+// getItemsForSales loads items by sale ID. Discovery paths leave out items hidden by
+// moderation; owner paths pass includeHidden so sellers still see them.
+func (s *MongoSalesService) getItemsForSales(ctx context.Context, saleIDs []string, includeHidden bool) (map[string][]models.Item, error) {
 	if len(saleIDs) == 0 {
 		return map[string][]models.Item{}, nil
 	}
 
+	filter := bson.M{"sale_id": bson.M{"$in": saleIDs}}
+	if !includeHidden {
+		filter["hidden"] = bson.M{"$ne": true}
+	}
 	cur, err := s.itemsColl.Find(
 		ctx,
-		bson.M{"sale_id": bson.M{"$in": saleIDs}},
+		filter,
 		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}),
 	)
 	if err != nil {
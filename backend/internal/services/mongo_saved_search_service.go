@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoSavedSearchService struct {
+	client        *mongo.Client
+	db            *mongo.Database
+	searchesCol   *mongo.Collection
+	alertsCol     *mongo.Collection
+	notifications NotificationPublisher
+}
+
+type mongoSavedSearchDoc struct {
+	ID         string        `bson:"_id"`
+	UserID     string        `bson:"user_id"`
+	Query      string        `bson:"query"`
+	Latitude   float64       `bson:"latitude"`
+	Longitude  float64       `bson:"longitude"`
+	RadiusMi   float64       `bson:"radius_mi"`
+	Categories []string      `bson:"categories,omitempty"`
+	MinPrice   *float64      `bson:"min_price,omitempty"`
+	MaxPrice   *float64      `bson:"max_price,omitempty"`
+	CreatedAt  time.Time     `bson:"created_at"`
+	Location   mongoGeoPoint `bson:"location"`
+}
+
+type mongoSavedSearchAlertDoc struct {
+	ID        string    `bson:"_id"`
+	UserID    string    `bson:"user_id"`
+	SearchID  string    `bson:"search_id"`
+	SaleID    string    `bson:"sale_id"`
+	ItemID    string    `bson:"item_id,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func NewMongoSavedSearchService(
+	ctx context.Context,
+	mongoURI string,
+	dbName string,
+	notifications NotificationPublisher,
+) (*MongoSavedSearchService, error) {
+	if mongoURI == "" || dbName == "" {
+		return nil, ErrSavedSearchBadInput
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	searches := db.Collection("saved_searches")
+	alerts := db.Collection("saved_search_alerts")
+
+	svc := &MongoSavedSearchService{
+		client:        client,
+		db:            db,
+		searchesCol:   searches,
+		alertsCol:     alerts,
+		notifications: notifications,
+	}
+
+	// Best-effort indexes. The unique alert index is what keeps a user from being
+	// alerted twice about the same sale.
+	_, _ = searches.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "location", Value: "2dsphere"}}},
+	})
+	_, _ = alerts.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "sale_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+
+	log.Printf("MongoDB connected (saved searches): db=%s", dbName)
+	return svc, nil
+}
+
+func (s *MongoSavedSearchService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func savedSearchDocToModel(d mongoSavedSearchDoc) *models.SavedSearch {
+	return &models.SavedSearch{
+		ID:         d.ID,
+		UserID:     d.UserID,
+		Query:      d.Query,
+		Latitude:   d.Latitude,
+		Longitude:  d.Longitude,
+		RadiusMi:   d.RadiusMi,
+		Categories: d.Categories,
+		MinPrice:   d.MinPrice,
+		MaxPrice:   d.MaxPrice,
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+func (s *MongoSavedSearchService) CreateSavedSearch(userID string, req *models.CreateSavedSearchRequest) (*models.SavedSearch, error) {
+	if userID == "" {
+		return nil, ErrSavedSearchBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := s.searchesCol.CountDocuments(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxSavedSearchesPerUser {
+		return nil, ErrSavedSearchLimit
+	}
+
+	radius := req.RadiusMi
+	if radius <= 0 {
+		radius = 10
+	}
+
+	doc := mongoSavedSearchDoc{
+		ID:         uuid.New().String(),
+		UserID:     userID,
+		Query:      strings.TrimSpace(req.Query),
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		RadiusMi:   radius,
+		Categories: req.Categories,
+		MinPrice:   req.MinPrice,
+		MaxPrice:   req.MaxPrice,
+		CreatedAt:  time.Now().UTC(),
+		Location: mongoGeoPoint{
+			Type:        "Point",
+			Coordinates: []float64{req.Longitude, req.Latitude},
+		},
+	}
+
+	if _, err := s.searchesCol.InsertOne(ctx, doc); err != nil {
+		return nil, err
+	}
+	return savedSearchDocToModel(doc), nil
+}
+
+func (s *MongoSavedSearchService) ListSavedSearches(userID string) ([]*models.SavedSearch, error) {
+	if userID == "" {
+		return nil, ErrSavedSearchBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := s.searchesCol.Find(
+		ctx,
+		bson.M{"user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.SavedSearch, 0)
+	for cur.Next(ctx) {
+		var d mongoSavedSearchDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, savedSearchDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *MongoSavedSearchService) DeleteSavedSearch(userID, searchID string) error {
+	if userID == "" || searchID == "" {
+		return ErrSavedSearchBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := s.searchesCol.DeleteOne(ctx, bson.M{"_id": searchID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+func (s *MongoSavedSearchService) ListAlerts(userID string, limit int) ([]*models.SavedSearchAlert, error) {
+	if userID == "" {
+		return nil, ErrSavedSearchBadInput
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 500
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := s.alertsCol.Find(
+		ctx,
+		bson.M{"user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.SavedSearchAlert, 0)
+	for cur.Next(ctx) {
+		var d mongoSavedSearchAlertDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, &models.SavedSearchAlert{
+			ID:        d.ID,
+			UserID:    d.UserID,
+			SearchID:  d.SearchID,
+			SaleID:    d.SaleID,
+			ItemID:    d.ItemID,
+			CreatedAt: d.CreatedAt,
+		})
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HandleSaleEvent evaluates saved searches against newly created sales and items and
+// alerts each matching user once per sale.
+func (s *MongoSavedSearchService) HandleSaleEvent(ctx context.Context, ev SaleEvent) {
+	switch ev.Type {
+	case SaleEventCreated, SaleEventItemAdded:
+	default:
+		return
+	}
+	if ev.Type == SaleEventItemAdded && ev.Item == nil {
+		return
+	}
+
+	// Candidate searches are those centered close enough that the widest allowed radius
+	// could reach the sale; savedSearchMatches applies each search's own radius.
+	radians := float64(models.MaxSavedSearchRadiusMi) / 3959.0
+	filter := bson.M{
+		"user_id": bson.M{"$ne": ev.Sale.UserID},
+		"location": bson.M{
+			"$geoWithin": bson.M{
+				"$centerSphere": bson.A{
+					bson.A{ev.Sale.Longitude, ev.Sale.Latitude},
+					radians,
+				},
+			},
+		},
+	}
+
+	cur, err := s.searchesCol.Find(ctx, filter)
+	if err != nil {
+		log.Printf("[saved-search] candidate lookup sale=%s err=%v", ev.Sale.ID, err)
+		return
+	}
+	defer cur.Close(ctx)
+
+	alerted := make(map[string]bool)
+	for cur.Next(ctx) {
+		var d mongoSavedSearchDoc
+		if err := cur.Decode(&d); err != nil {
+			log.Printf("[saved-search] decode err=%v", err)
+			continue
+		}
+		if alerted[d.UserID] {
+			continue
+		}
+		search := savedSearchDocToModel(d)
+		if !savedSearchMatches(search, ev.Sale, ev.Item) {
+			continue
+		}
+		alerted[d.UserID] = true
+		s.alert(ctx, search, ev)
+	}
+	if err := cur.Err(); err != nil {
+		log.Printf("[saved-search] cursor sale=%s err=%v", ev.Sale.ID, err)
+	}
+}
+
+func (s *MongoSavedSearchService) alert(ctx context.Context, search *models.SavedSearch, ev SaleEvent) {
+	doc := mongoSavedSearchAlertDoc{
+		ID:        uuid.New().String(),
+		UserID:    search.UserID,
+		SearchID:  search.ID,
+		SaleID:    ev.Sale.ID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if ev.Item != nil {
+		doc.ItemID = ev.Item.ID
+	}
+
+	if _, err := s.alertsCol.InsertOne(ctx, doc); err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			log.Printf("[saved-search] record alert user=%s sale=%s err=%v", search.UserID, ev.Sale.ID, err)
+		}
+		// Already alerted about this sale.
+		return
+	}
+
+	if s.notifications == nil {
+		return
+	}
+
+	body := ev.Sale.Title
+	if ev.Item != nil {
+		body = fmt.Sprintf("%s at %s", ev.Item.Name, ev.Sale.Title)
+	}
+	title := "New match for your saved search"
+	if search.Query != "" {
+		title = fmt.Sprintf("New match for \"%s\"", search.Query)
+	}
+
+	data := map[string]string{
+		"sale_id":   ev.Sale.ID,
+		"search_id": search.ID,
+	}
+	if doc.ItemID != "" {
+		data["item_id"] = doc.ItemID
+	}
+
+	n := &models.Notification{
+		ID:        uuid.New().String(),
+		UserID:    search.UserID,
+		Type:      models.NotificationSavedSearchMatch,
+		Title:     title,
+		Body:      body,
+		Data:      data,
+		CreatedAt: doc.CreatedAt,
+	}
+	if err := s.notifications.Publish(ctx, n); err != nil {
+		log.Printf("[saved-search] notify user=%s sale=%s err=%v", search.UserID, ev.Sale.ID, err)
+	}
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoSupportService struct {
+	client     *mongo.Client
+	db         *mongo.Database
+	ticketsCol *mongo.Collection
+}
+
+type mongoSupportReplyDoc struct {
+	Body      string    `bson:"body"`
+	AdminID   string    `bson:"admin_id"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type mongoSupportContextDoc struct {
+	SaleID      string   `bson:"sale_id,omitempty"`
+	SaleTitle   string   `bson:"sale_title,omitempty"`
+	SellerID    string   `bson:"seller_id,omitempty"`
+	ItemIDs     []string `bson:"item_ids,omitempty"`
+	ItemNames   []string `bson:"item_names,omitempty"`
+	AppVersion  string   `bson:"app_version,omitempty"`
+	Platform    string   `bson:"platform,omitempty"`
+	OSVersion   string   `bson:"os_version,omitempty"`
+	DeviceModel string   `bson:"device_model,omitempty"`
+}
+
+type mongoSupportTicketDoc struct {
+	ID         string                  `bson:"_id"`
+	UserID     string                  `bson:"user_id,omitempty"`
+	Name       string                  `bson:"name"`
+	Email      string                  `bson:"email"`
+	Subject    string                  `bson:"subject,omitempty"`
+	Message    string                  `bson:"message"`
+	Context    *mongoSupportContextDoc `bson:"context,omitempty"`
+	Status     string                  `bson:"status"`
+	SecretHash string                  `bson:"secret_hash"`
+	Replies    []mongoSupportReplyDoc  `bson:"replies"`
+	CreatedAt  time.Time               `bson:"created_at"`
+	UpdatedAt  time.Time               `bson:"updated_at"`
+	ResolvedAt *time.Time              `bson:"resolved_at,omitempty"`
+}
+
+func NewMongoSupportService(ctx context.Context, mongoURI, dbName string) (*MongoSupportService, error) {
+	if mongoURI == "" || dbName == "" {
+		return nil, ErrSupportBadInput
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	tickets := db.Collection("support_tickets")
+
+	// Best-effort indexes. The ticket number is the _id.
+	_, _ = tickets.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	})
+
+	log.Printf("MongoDB connected (support): db=%s", dbName)
+	return &MongoSupportService{
+		client:     client,
+		db:         db,
+		ticketsCol: tickets,
+	}, nil
+}
+
+func (s *MongoSupportService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func supportTicketDocToModel(d mongoSupportTicketDoc) *models.SupportTicket {
+	replies := make([]models.SupportTicketReply, 0, len(d.Replies))
+	for _, r := range d.Replies {
+		replies = append(replies, models.SupportTicketReply{Body: r.Body, AdminID: r.AdminID, CreatedAt: r.CreatedAt})
+	}
+	m := &models.SupportTicket{
+		ID:         d.ID,
+		UserID:     d.UserID,
+		Name:       d.Name,
+		Email:      d.Email,
+		Subject:    d.Subject,
+		Message:    d.Message,
+		Status:     d.Status,
+		Replies:    replies,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+		ResolvedAt: d.ResolvedAt,
+	}
+	if c := d.Context; c != nil {
+		m.Context = &models.SupportContext{
+			SaleID:      c.SaleID,
+			SaleTitle:   c.SaleTitle,
+			SellerID:    c.SellerID,
+			ItemIDs:     c.ItemIDs,
+			ItemNames:   c.ItemNames,
+			AppVersion:  c.AppVersion,
+			Platform:    c.Platform,
+			OSVersion:   c.OSVersion,
+			DeviceModel: c.DeviceModel,
+		}
+	}
+	return m
+}
+
+func (s *MongoSupportService) CreateTicket(t *models.SupportTicket) (string, error) {
+	if t == nil || t.ID == "" || strings.TrimSpace(t.Email) == "" {
+		return "", ErrSupportBadInput
+	}
+
+	secret, err := newSupportSecret()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	doc := mongoSupportTicketDoc{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		Name:       t.Name,
+		Email:      t.Email,
+		Subject:    t.Subject,
+		Message:    t.Message,
+		Status:     models.SupportTicketOpen,
+		SecretHash: hashSupportSecret(secret),
+		Replies:    []mongoSupportReplyDoc{},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if c := t.Context; c != nil {
+		doc.Context = &mongoSupportContextDoc{
+			SaleID:      c.SaleID,
+			SaleTitle:   c.SaleTitle,
+			SellerID:    c.SellerID,
+			ItemIDs:     c.ItemIDs,
+			ItemNames:   c.ItemNames,
+			AppVersion:  c.AppVersion,
+			Platform:    c.Platform,
+			OSVersion:   c.OSVersion,
+			DeviceModel: c.DeviceModel,
+		}
+	}
+	if _, err := s.ticketsCol.InsertOne(ctx, doc); err != nil {
+		return "", err
+	}
+
+	*t = *supportTicketDocToModel(doc)
+	return secret, nil
+}
+
+func (s *MongoSupportService) GetTicketWithSecret(ticketID, secret string) (*models.SupportTicket, error) {
+	if ticketID == "" || secret == "" {
+		return nil, ErrSupportTicketNotFound
+	}
+
+	d, err := s.findTicket(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if !supportSecretMatches(secret, d.SecretHash) {
+		return nil, ErrSupportTicketNotFound
+	}
+	return supportTicketDocToModel(*d), nil
+}
+
+func (s *MongoSupportService) GetTicket(ticketID string) (*models.SupportTicket, error) {
+	if ticketID == "" {
+		return nil, ErrSupportBadInput
+	}
+	d, err := s.findTicket(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return supportTicketDocToModel(*d), nil
+}
+
+func (s *MongoSupportService) findTicket(ticketID string) (*mongoSupportTicketDoc, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var d mongoSupportTicketDoc
+	if err := s.ticketsCol.FindOne(ctx, bson.M{"_id": ticketID}).Decode(&d); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrSupportTicketNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (s *MongoSupportService) ListTickets(status string, limit int) ([]*models.SupportTicket, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	cur, err := s.ticketsCol.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.SupportTicket, 0)
+	for cur.Next(ctx) {
+		var d mongoSupportTicketDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, supportTicketDocToModel(d))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *MongoSupportService) AddReply(ticketID, adminID string, req *models.ReplySupportTicketRequest) (*models.SupportTicket, error) {
+	if ticketID == "" || adminID == "" || req == nil {
+		return nil, ErrSupportBadInput
+	}
+
+	status := req.Status
+	if status == "" {
+		status = models.SupportTicketPending
+	}
+
+	now := time.Now().UTC()
+	reply := mongoSupportReplyDoc{
+		Body:      strings.TrimSpace(req.Body),
+		AdminID:   adminID,
+		CreatedAt: now,
+	}
+	return s.update(ticketID, status, now, bson.M{"$push": bson.M{"replies": reply}})
+}
+
+func (s *MongoSupportService) UpdateStatus(ticketID, status string) (*models.SupportTicket, error) {
+	if ticketID == "" || !models.IsSupportTicketStatus(status) {
+		return nil, ErrSupportBadInput
+	}
+	return s.update(ticketID, status, time.Now().UTC(), bson.M{})
+}
+
+// update applies extra (e.g. a $push) along with the status change and timestamps.
+func (s *MongoSupportService) update(ticketID, status string, now time.Time, extra bson.M) (*models.SupportTicket, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := extra
+	set := bson.M{"status": status, "updated_at": now}
+	if status == models.SupportTicketResolved {
+		set["resolved_at"] = now
+	} else {
+		update["$unset"] = bson.M{"resolved_at": ""}
+	}
+	update["$set"] = set
+
+	res := s.ticketsCol.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": ticketID},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+
+	var updated mongoSupportTicketDoc
+	if err := res.Decode(&updated); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrSupportTicketNotFound
+		}
+		return nil, err
+	}
+	return supportTicketDocToModel(updated), nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -33,10 +34,14 @@ func NewMongoUserFlagService(ctx context.Context, mongoURI, dbName string) (*Mon
 	db := client.Database(dbName)
 	col := db.Collection("user_flags")
 
-	_, _ = col.Indexes().CreateOne(ctx, mongo.IndexModel{
+	// Unlike most indexes this one is required: AddStrike's upsert relies on it to
+	// keep one record per user.
+	if _, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
 		Keys:    bson.D{{Key: "user_id", Value: 1}},
 		Options: options.Index().SetUnique(true),
-	})
+	}); err != nil {
+		return nil, fmt.Errorf("user_flags: unique user_id index: %w", err)
+	}
 
 	return &MongoUserFlagService{client: client, db: db, col: col}, nil
 }
@@ -45,28 +50,64 @@ func (s *MongoUserFlagService) Close(ctx context.Context) error {
 	return s.client.Disconnect(ctx)
 }
 
-// AddStrike increments the strike counter for the user and returns the updated record.
-func (s *MongoUserFlagService) AddStrike(ctx context.Context, userID string) (*models.UserFlag, error) {
+// AddStrike records a strike for the user, quoting the moderation decision behind it,
+// and returns the updated record. A decision counts once: adding a strike for a
+// decisionID that is already in the log changes nothing.
+func (s *MongoUserFlagService) AddStrike(ctx context.Context, userID, reason, decisionID string) (*models.UserFlag, error) {
 	now := time.Now().UTC()
 	update := bson.M{
-		"$inc": bson.M{"strikes": 1},
-		"$set": bson.M{"last_strike_at": now, "updated_at": now},
+		"$inc":  bson.M{"strikes": 1},
+		"$push": bson.M{"strike_log": models.Strike{Reason: reason, DecisionID: decisionID, At: now}},
+		"$set":  bson.M{"last_strike_at": now, "updated_at": now},
 		"$setOnInsert": bson.M{
-			"user_id":  userID,
-			"strikes":  0,
-			"updated_at": now,
+			"user_id": userID,
 		},
 	}
+	filter := bson.M{"user_id": userID}
+	if decisionID != "" {
+		filter["strike_log.decision_id"] = bson.M{"$ne": decisionID}
+	}
 
-	_, err := s.col.UpdateOne(ctx, bson.M{"user_id": userID}, update, options.Update().SetUpsert(true))
+	_, err := s.col.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// The user's record exists but didn't match: either the decision is already
+		// logged, or the record was created concurrently. Retrying without the upsert
+		// tells the two apart.
+		_, err = s.col.UpdateOne(ctx, filter, update)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	return s.Get(ctx, userID)
+}
+
+// Get returns the user's record, or an empty one if they have never had a strike.
+func (s *MongoUserFlagService) Get(ctx context.Context, userID string) (*models.UserFlag, error) {
 	var out models.UserFlag
 	if err := s.col.FindOne(ctx, bson.M{"user_id": userID}).Decode(&out); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return &models.UserFlag{UserID: userID}, nil
+		}
 		return nil, err
 	}
 	return &out, nil
 }
 
+// RemoveStrike deletes the strike recorded for decisionID, e.g. after a successful
+// appeal. It reports whether there was one.
+func (s *MongoUserFlagService) RemoveStrike(ctx context.Context, userID, decisionID string) (bool, error) {
+	now := time.Now().UTC()
+	res, err := s.col.UpdateOne(ctx,
+		bson.M{"user_id": userID, "strike_log.decision_id": decisionID},
+		bson.M{
+			"$pull": bson.M{"strike_log": bson.M{"decision_id": decisionID}},
+			"$inc":  bson.M{"strikes": -1},
+			"$set":  bson.M{"updated_at": now},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
@@ -0,0 +1,417 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+type MongoWantedService struct {
+	client        *mongo.Client
+	db            *mongo.Database
+	postsCol      *mongo.Collection
+	matchesCol    *mongo.Collection
+	notifications NotificationPublisher
+}
+
+type mongoWantedDoc struct {
+	ID          string        `bson:"_id"`
+	UserID      string        `bson:"user_id"`
+	Description string        `bson:"description"`
+	Category    string        `bson:"category,omitempty"`
+	MaxPrice    *float64      `bson:"max_price,omitempty"`
+	Latitude    float64       `bson:"latitude"`
+	Longitude   float64       `bson:"longitude"`
+	RadiusMi    float64       `bson:"radius_mi"`
+	CreatedAt   time.Time     `bson:"created_at"`
+	Location    mongoGeoPoint `bson:"location"`
+}
+
+type mongoWantedMatchDoc struct {
+	ID        string    `bson:"_id"`
+	WantedID  string    `bson:"wanted_id"`
+	BuyerID   string    `bson:"buyer_id"`
+	SellerID  string    `bson:"seller_id"`
+	SaleID    string    `bson:"sale_id"`
+	ItemID    string    `bson:"item_id"`
+	ItemName  string    `bson:"item_name"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func NewMongoWantedService(
+	ctx context.Context,
+	mongoURI string,
+	dbName string,
+	notifications NotificationPublisher,
+) (*MongoWantedService, error) {
+	if mongoURI == "" || dbName == "" {
+		return nil, ErrWantedBadInput
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db := client.Database(dbName)
+	posts := db.Collection("wanted_posts")
+	matches := db.Collection("wanted_matches")
+
+	svc := &MongoWantedService{
+		client:        client,
+		db:            db,
+		postsCol:      posts,
+		matchesCol:    matches,
+		notifications: notifications,
+	}
+
+	// Best-effort indexes. The unique match index keeps an item from matching the same
+	// post twice when it is edited after the first match.
+	_, _ = posts.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "location", Value: "2dsphere"}}},
+	})
+	_, _ = matches.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "wanted_id", Value: 1}, {Key: "item_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "buyer_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "seller_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+
+	log.Printf("MongoDB connected (wanted): db=%s", dbName)
+	return svc, nil
+}
+
+func (s *MongoWantedService) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+func wantedDocToModel(d mongoWantedDoc) *models.WantedPost {
+	return &models.WantedPost{
+		ID:          d.ID,
+		UserID:      d.UserID,
+		Description: d.Description,
+		Category:    d.Category,
+		MaxPrice:    d.MaxPrice,
+		Latitude:    d.Latitude,
+		Longitude:   d.Longitude,
+		RadiusMi:    d.RadiusMi,
+		CreatedAt:   d.CreatedAt,
+	}
+}
+
+func (s *MongoWantedService) CreateWanted(userID string, req *models.CreateWantedRequest) (*models.WantedPost, error) {
+	if userID == "" {
+		return nil, ErrWantedBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := s.postsCol.CountDocuments(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxWantedPostsPerUser {
+		return nil, ErrWantedLimit
+	}
+
+	radius := req.RadiusMi
+	if radius <= 0 {
+		radius = 10
+	}
+
+	// Store the canonical spelling so category filters compare cleanly.
+	category := ""
+	for _, c := range models.ItemCategories {
+		if strings.EqualFold(c, req.Category) {
+			category = c
+			break
+		}
+	}
+
+	doc := mongoWantedDoc{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Description: strings.TrimSpace(req.Description),
+		Category:    category,
+		MaxPrice:    req.MaxPrice,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		RadiusMi:    radius,
+		CreatedAt:   time.Now().UTC(),
+		Location: mongoGeoPoint{
+			Type:        "Point",
+			Coordinates: []float64{req.Longitude, req.Latitude},
+		},
+	}
+
+	if _, err := s.postsCol.InsertOne(ctx, doc); err != nil {
+		return nil, err
+	}
+	return wantedDocToModel(doc), nil
+}
+
+func (s *MongoWantedService) ListWanted(userID string) ([]*models.WantedPost, error) {
+	if userID == "" {
+		return nil, ErrWantedBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return s.findPosts(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}), nil)
+}
+
+func (s *MongoWantedService) DeleteWanted(userID, wantedID string) error {
+	if userID == "" || wantedID == "" {
+		return ErrWantedBadInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := s.postsCol.DeleteOne(ctx, bson.M{"_id": wantedID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrWantedNotFound
+	}
+	_, _ = s.matchesCol.DeleteMany(ctx, bson.M{"wanted_id": wantedID})
+	return nil
+}
+
+func (s *MongoWantedService) ListNearby(userID string, lat, lng float64, limit int) ([]*models.WantedPost, error) {
+	if lat == 0 && lng == 0 {
+		return nil, ErrWantedBadInput
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 200
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := wantedCandidateFilter(lat, lng)
+	if userID != "" {
+		filter["user_id"] = bson.M{"$ne": userID}
+	}
+
+	// Candidates are sorted newest first; each post's own radius decides whether the
+	// seller is inside its area.
+	covers := func(p *models.WantedPost) bool {
+		radius := p.RadiusMi
+		if radius <= 0 {
+			radius = 10
+		}
+		return haversineDistance(p.Latitude, p.Longitude, lat, lng) <= radius
+	}
+	posts, err := s.findPosts(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}), covers)
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) > limit {
+		posts = posts[:limit]
+	}
+	return posts, nil
+}
+
+func (s *MongoWantedService) ListMatches(userID string, limit int) ([]*models.WantedMatch, error) {
+	if userID == "" {
+		return nil, ErrWantedBadInput
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 500
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := s.matchesCol.Find(
+		ctx,
+		bson.M{"$or": []bson.M{{"buyer_id": userID}, {"seller_id": userID}}},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.WantedMatch, 0)
+	for cur.Next(ctx) {
+		var d mongoWantedMatchDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, &models.WantedMatch{
+			ID:        d.ID,
+			WantedID:  d.WantedID,
+			BuyerID:   d.BuyerID,
+			SellerID:  d.SellerID,
+			SaleID:    d.SaleID,
+			ItemID:    d.ItemID,
+			ItemName:  d.ItemName,
+			CreatedAt: d.CreatedAt,
+		})
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HandleSaleEvent matches wanted posts against items as they are added or edited and
+// tells both the buyer and the seller about each new match.
+func (s *MongoWantedService) HandleSaleEvent(ctx context.Context, ev SaleEvent) {
+	switch ev.Type {
+	case SaleEventItemAdded, SaleEventItemUpdated:
+	default:
+		return
+	}
+	if ev.Item == nil || ev.Sale == nil {
+		return
+	}
+
+	filter := wantedCandidateFilter(ev.Sale.Latitude, ev.Sale.Longitude)
+	filter["user_id"] = bson.M{"$ne": ev.Sale.UserID}
+
+	match := func(p *models.WantedPost) bool { return wantedMatchesItem(p, ev.Sale, ev.Item) }
+	posts, err := s.findPosts(ctx, filter, nil, match)
+	if err != nil {
+		log.Printf("[wanted] candidate lookup sale=%s item=%s err=%v", ev.Sale.ID, ev.Item.ID, err)
+		return
+	}
+	for _, p := range posts {
+		s.recordMatch(ctx, p, ev)
+	}
+}
+
+// wantedCandidateFilter selects posts centered close enough that the widest allowed
+// radius could reach the point.
+func wantedCandidateFilter(lat, lng float64) bson.M {
+	radians := float64(models.MaxWantedRadiusMi) / 3959.0
+	return bson.M{
+		"location": bson.M{
+			"$geoWithin": bson.M{
+				"$centerSphere": bson.A{
+					bson.A{lng, lat},
+					radians,
+				},
+			},
+		},
+	}
+}
+
+// findPosts runs filter and keeps the posts for which keep returns true (all of them
+// when keep is nil).
+func (s *MongoWantedService) findPosts(ctx context.Context, filter bson.M, opts *options.FindOptions, keep func(*models.WantedPost) bool) ([]*models.WantedPost, error) {
+	if opts == nil {
+		opts = options.Find()
+	}
+	cur, err := s.postsCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]*models.WantedPost, 0)
+	for cur.Next(ctx) {
+		var d mongoWantedDoc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		p := wantedDocToModel(d)
+		if keep != nil && !keep(p) {
+			continue
+		}
+		out = append(out, p)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *MongoWantedService) recordMatch(ctx context.Context, post *models.WantedPost, ev SaleEvent) {
+	doc := mongoWantedMatchDoc{
+		ID:        uuid.New().String(),
+		WantedID:  post.ID,
+		BuyerID:   post.UserID,
+		SellerID:  ev.Sale.UserID,
+		SaleID:    ev.Sale.ID,
+		ItemID:    ev.Item.ID,
+		ItemName:  ev.Item.Name,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if _, err := s.matchesCol.InsertOne(ctx, doc); err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			log.Printf("[wanted] record match wanted=%s item=%s err=%v", post.ID, ev.Item.ID, err)
+		}
+		// Already matched.
+		return
+	}
+
+	if s.notifications == nil {
+		return
+	}
+
+	data := map[string]string{
+		"wanted_id": post.ID,
+		"match_id":  doc.ID,
+		"sale_id":   ev.Sale.ID,
+		"item_id":   ev.Item.ID,
+	}
+	notes := []*models.Notification{
+		{
+			UserID: post.UserID,
+			Type:   models.NotificationWantedMatchBuyer,
+			Title:  fmt.Sprintf("Found something like \"%s\"", truncateText(post.Description, 40)),
+			Body:   fmt.Sprintf("%s at %s", ev.Item.Name, ev.Sale.Title),
+		},
+		{
+			UserID: ev.Sale.UserID,
+			Type:   models.NotificationWantedMatchSeller,
+			Title:  "A buyer nearby is looking for this",
+			Body:   fmt.Sprintf("Your %s matches a wanted post: \"%s\"", ev.Item.Name, truncateText(post.Description, 80)),
+		},
+	}
+	for _, n := range notes {
+		n.ID = uuid.New().String()
+		n.Data = data
+		n.CreatedAt = doc.CreatedAt
+		if err := s.notifications.Publish(ctx, n); err != nil {
+			log.Printf("[wanted] notify user=%s wanted=%s err=%v", n.UserID, post.ID, err)
+		}
+	}
+}
+
+// truncateText shortens s to at most n runes, adding an ellipsis when cut.
+func truncateText(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(r[:n])) + "…"
+}
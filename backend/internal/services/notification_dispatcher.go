@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+const (
+	// heldRetryDelay is how long a held push waits before another try after a failed
+	// delivery; heldMaxAge is how old it may get before it is given up on.
+	heldRetryDelay = 5 * time.Minute
+	heldMaxAge     = 48 * time.Hour
+)
+
+// NotificationDispatcher is the production NotificationPublisher. It applies the
+// recipient's preferences, records the notification in their inbox, emails it if they
+// opted in, then resolves their registered devices and pushes it through the Notifier.
+// Pushes that arrive during quiet hours, or low-priority ones for users in digest mode,
+// are held and sent later by RunHeldDeliveries.
+type NotificationDispatcher struct {
+	inbox     NotificationInbox
+	holds     NotificationHoldQueue
+	prefs     NotificationPreferenceService
+	devices   DeviceService
+	notifier  Notifier
+	mailer    Mailer
+	addresses EmailAddressResolver
+}
+
+// NewNotificationDispatcher wires delivery channels. Any of them may be nil; with no
+// inbox and no notifier, notifications are only logged. Without prefs everyone gets the
+// defaults; without holds, pushes during quiet hours are dropped (the inbox keeps them).
+// Email needs both mailer and addresses.
+func NewNotificationDispatcher(
+	inbox NotificationInbox,
+	holds NotificationHoldQueue,
+	prefs NotificationPreferenceService,
+	devices DeviceService,
+	notifier Notifier,
+	mailer Mailer,
+	addresses EmailAddressResolver,
+) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		inbox:     inbox,
+		holds:     holds,
+		prefs:     prefs,
+		devices:   devices,
+		notifier:  notifier,
+		mailer:    mailer,
+		addresses: addresses,
+	}
+}
+
+func (d *NotificationDispatcher) Publish(ctx context.Context, n *models.Notification) error {
+	if n == nil || n.UserID == "" {
+		return nil
+	}
+	if n.ID == "" {
+		n.ID = uuid.New().String()
+	}
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now().UTC()
+	}
+
+	prefs := d.preferences(ctx, n.UserID)
+	channels := prefs.ChannelsFor(n.Type)
+
+	if d.inbox != nil && channels.InApp {
+		// Persist before pushing so the push payload's notification_id resolves in the inbox.
+		if err := d.inbox.Save(ctx, n); err != nil {
+			log.Printf("[notify] inbox save user=%s type=%s err=%v", n.UserID, n.Type, err)
+		}
+	}
+
+	if channels.Email && d.mailer != nil && d.addresses != nil {
+		// Email is not intrusive, so it ignores quiet hours and digest mode.
+		if err := d.email(ctx, n); err != nil {
+			log.Printf("[notify] email user=%s type=%s err=%v", n.UserID, n.Type, err)
+		}
+	}
+
+	if d.notifier == nil || d.devices == nil {
+		if d.inbox != nil || d.mailer != nil {
+			return nil
+		}
+		return LogNotificationPublisher{}.Publish(ctx, n)
+	}
+	if !channels.Push {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	if prefs.Digest && models.IsLowPriorityNotification(n.Type) {
+		return d.hold(ctx, n, nextDigestAt(prefs, now))
+	}
+	if end, quiet := quietHoursEnd(prefs, now); quiet {
+		return d.hold(ctx, n, end)
+	}
+	return d.push(ctx, n)
+}
+
+// preferences loads the user's settings, falling back to the defaults so a preferences
+// outage never blocks delivery.
+func (d *NotificationDispatcher) preferences(ctx context.Context, userID string) *models.NotificationPreferences {
+	if d.prefs == nil {
+		return models.DefaultNotificationPreferences(userID)
+	}
+	prefs, err := d.prefs.GetPreferences(ctx, userID)
+	if err != nil || prefs == nil {
+		if err != nil {
+			log.Printf("[notify] load preferences user=%s err=%v", userID, err)
+		}
+		return models.DefaultNotificationPreferences(userID)
+	}
+	return prefs
+}
+
+func (d *NotificationDispatcher) hold(ctx context.Context, n *models.Notification, until time.Time) error {
+	if d.holds == nil {
+		return nil
+	}
+	return d.holds.Hold(ctx, n, until)
+}
+
+// RunHeldDeliveries sends held pushes once they are due, until ctx is cancelled. A user
+// with several due notifications gets a single digest push rather than a burst.
+func (d *NotificationDispatcher) RunHeldDeliveries(ctx context.Context, interval time.Duration) {
+	if d.holds == nil || d.notifier == nil || d.devices == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.deliverHeld(ctx)
+		}
+	}
+}
+
+func (d *NotificationDispatcher) deliverHeld(ctx context.Context) {
+	due, err := d.holds.TakeDue(ctx, time.Now().UTC(), 500)
+	if err != nil {
+		log.Printf("[notify] take held notifications err=%v", err)
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	byUser := make(map[string][]*models.Notification)
+	order := make([]string, 0)
+	for _, n := range due {
+		if _, ok := byUser[n.UserID]; !ok {
+			order = append(order, n.UserID)
+		}
+		byUser[n.UserID] = append(byUser[n.UserID], n)
+	}
+
+	for _, userID := range order {
+		held := byUser[userID]
+		n := held[0]
+		if len(held) > 1 {
+			n = &models.Notification{
+				ID:        uuid.New().String(),
+				UserID:    userID,
+				Type:      models.NotificationDigest,
+				Title:     "While you were away",
+				Body:      fmt.Sprintf("You have %d new updates.", len(held)),
+				CreatedAt: time.Now().UTC(),
+			}
+		}
+		if err := d.push(ctx, n); err != nil {
+			log.Printf("[notify] deliver held user=%s count=%d err=%v", userID, len(held), err)
+			d.requeueHeld(ctx, held)
+		}
+	}
+}
+
+// requeueHeld puts notifications whose push failed back on the hold queue to retry
+// after heldRetryDelay. Ones older than heldMaxAge are dropped instead, so a push
+// that keeps failing doesn't cycle forever; the inbox still has them.
+func (d *NotificationDispatcher) requeueHeld(ctx context.Context, held []*models.Notification) {
+	now := time.Now().UTC()
+	for _, n := range held {
+		if now.Sub(n.CreatedAt) > heldMaxAge {
+			log.Printf("[notify] drop held notification=%s user=%s: too old to retry", n.ID, n.UserID)
+			continue
+		}
+		if err := d.holds.Hold(ctx, n, now.Add(heldRetryDelay)); err != nil {
+			log.Printf("[notify] requeue held notification=%s user=%s err=%v", n.ID, n.UserID, err)
+		}
+	}
+}
+
+func (d *NotificationDispatcher) email(ctx context.Context, n *models.Notification) error {
+	to, name, err := d.addresses.EmailForUser(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+	if to == "" {
+		return nil
+	}
+
+	msg, err := RenderEmail("notification", n)
+	if err != nil {
+		return err
+	}
+	msg.To = to
+	msg.ToName = name
+	msg.Tags = map[string]string{"notification_id": n.ID, "type": n.Type}
+	return d.mailer.Send(ctx, msg)
+}
+
+func (d *NotificationDispatcher) push(ctx context.Context, n *models.Notification) error {
+	tokens, err := d.devices.ListUserTokens(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	data := map[string]string{"type": n.Type, "notification_id": n.ID}
+	for k, v := range n.Data {
+		data[k] = v
+	}
+
+	invalid, err := d.notifier.Send(ctx, tokens, PushMessage{Title: n.Title, Body: n.Body, Data: data})
+	if len(invalid) > 0 {
+		if rmErr := d.devices.RemoveTokens(ctx, invalid); rmErr != nil {
+			log.Printf("[notify] remove %d stale tokens user=%s err=%v", len(invalid), n.UserID, rmErr)
+		}
+	}
+	return err
+}
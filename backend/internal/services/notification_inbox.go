@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrNotificationBadInput = errors.New("bad input")
+)
+
+// DefaultNotificationTTL is how long inbox entries live when no TTL is configured.
+const DefaultNotificationTTL = 30 * 24 * time.Hour
+
+// NotificationInbox persists every notification so users can catch up on anything they
+// dismissed. Entries expire after the configured TTL.
+type NotificationInbox interface {
+	Save(ctx context.Context, n *models.Notification) error
+	// List returns up to limit notifications created strictly before the cursor time
+	// (zero means from the newest), along with the user's unread count.
+	List(userID string, before time.Time, limit int) (*models.NotificationPage, error)
+	UnreadCount(userID string) (int, error)
+	MarkRead(userID, notificationID string) (*models.Notification, error)
+	// MarkAllRead returns the number of notifications that changed.
+	MarkAllRead(userID string) (int, error)
+}
+
+// NotificationHoldQueue defers pushes that arrive during quiet hours or that the user
+// wants batched into a digest.
+type NotificationHoldQueue interface {
+	Hold(ctx context.Context, n *models.Notification, deliverAfter time.Time) error
+	// TakeDue removes and returns up to limit held notifications due at or before now,
+	// oldest first. Each notification is returned to exactly one caller.
+	TakeDue(ctx context.Context, now time.Time, limit int) ([]*models.Notification, error)
+}
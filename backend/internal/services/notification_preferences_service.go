@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var ErrNotificationPreferencesBadInput = errors.New("bad input")
+
+// NotificationPreferenceService stores per-user delivery settings. The dispatcher
+// consults it for every notification, so producers never check preferences themselves.
+type NotificationPreferenceService interface {
+	// GetPreferences returns the stored preferences, or the defaults if none are stored.
+	GetPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error)
+	UpdatePreferences(userID string, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error)
+}
+
+// quietHoursEnd reports whether now falls inside the user's quiet hours and, if so,
+// when they end.
+func quietHoursEnd(prefs *models.NotificationPreferences, now time.Time) (time.Time, bool) {
+	if prefs == nil || !prefs.QuietHours.Enabled {
+		return time.Time{}, false
+	}
+	start, ok1 := models.ParseClock(prefs.QuietHours.Start)
+	end, ok2 := models.ParseClock(prefs.QuietHours.End)
+	if !ok1 || !ok2 || start == end {
+		return time.Time{}, false
+	}
+
+	local := now.In(prefs.Location())
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	minute := local.Hour()*60 + local.Minute()
+
+	if start < end {
+		// Same-day window, e.g. 13:00-15:00.
+		if minute >= start && minute < end {
+			return midnight.Add(time.Duration(end) * time.Minute), true
+		}
+		return time.Time{}, false
+	}
+
+	// Window spans midnight, e.g. 22:00-07:00.
+	if minute >= start {
+		return midnight.AddDate(0, 0, 1).Add(time.Duration(end) * time.Minute), true
+	}
+	if minute < end {
+		return midnight.Add(time.Duration(end) * time.Minute), true
+	}
+	return time.Time{}, false
+}
+
+// nextDigestAt returns the next occurrence of the user's digest hour after now.
+func nextDigestAt(prefs *models.NotificationPreferences, now time.Time) time.Time {
+	local := now.In(prefs.Location())
+	at := time.Date(local.Year(), local.Month(), local.Day(), prefs.DigestHour, 0, 0, 0, local.Location())
+	if !at.After(local) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at
+}
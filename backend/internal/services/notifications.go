@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+// NotificationPublisher is the single entry point every notification producer uses.
+type NotificationPublisher interface {
+	Publish(ctx context.Context, n *models.Notification) error
+}
+
+// LogNotificationPublisher writes notifications to the server log. It is the default
+// until a delivery channel is configured.
+type LogNotificationPublisher struct{}
+
+func (LogNotificationPublisher) Publish(ctx context.Context, n *models.Notification) error {
+	if n == nil {
+		return nil
+	}
+	log.Printf("[notify] user=%s type=%s title=%q data=%v", n.UserID, n.Type, n.Title, n.Data)
+	return nil
+}
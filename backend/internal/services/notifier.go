@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// PushMessage is the provider-neutral payload of a push notification.
+type PushMessage struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// Notifier delivers push notifications to device tokens.
+type Notifier interface {
+	// Send delivers msg to every token. It returns the tokens the provider reported as
+	// no longer registered so callers can forget them; err is only set when the send as
+	// a whole failed.
+	Send(ctx context.Context, tokens []string, msg PushMessage) (invalid []string, err error)
+}
+
+// FakePush is one call recorded by FakeNotifier.
+type FakePush struct {
+	Tokens  []string
+	Message PushMessage
+	SentAt  time.Time
+}
+
+// FakeNotifier records sends instead of delivering them. Use it in local development
+// and tests; tokens listed in Invalid are reported back as unregistered.
+type FakeNotifier struct {
+	mu      sync.Mutex
+	sends   []FakePush
+	Invalid map[string]bool
+}
+
+func NewFakeNotifier() *FakeNotifier {
+	return &FakeNotifier{Invalid: map[string]bool{}}
+}
+
+func (f *FakeNotifier) Send(ctx context.Context, tokens []string, msg PushMessage) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sends = append(f.sends, FakePush{
+		Tokens:  append([]string(nil), tokens...),
+		Message: msg,
+		SentAt:  time.Now().UTC(),
+	})
+	log.Printf("[push:fake] tokens=%d title=%q", len(tokens), msg.Title)
+
+	invalid := make([]string, 0)
+	for _, t := range tokens {
+		if f.Invalid[t] {
+			invalid = append(invalid, t)
+		}
+	}
+	return invalid, nil
+}
+
+// Sends returns a copy of everything sent so far.
+func (f *FakeNotifier) Sends() []FakePush {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakePush(nil), f.sends...)
+}
+
+// Reset forgets recorded sends.
+func (f *FakeNotifier) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sends = nil
+}
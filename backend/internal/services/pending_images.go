@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoImageReferences is returned for an upload type without a record store. Retrying
+// can't help, so callers treat it as done.
+var ErrNoImageReferences = errors.New("pending images: no references for upload type")
+
+// PendingImageReferences updates the records that point at a pending/ image once
+// moderation has decided on it. Records are matched by the pending path, so the
+// upload type only picks the collection.
+type PendingImageReferences struct {
+	sales    *MongoSalesService
+	profiles *MongoProfileService
+	curb     *MongoCurbAlertService
+}
+
+// NewPendingImageReferences accepts nil for any store that isn't wired up; images of
+// that upload type are then promoted or deleted without touching records.
+func NewPendingImageReferences(sales *MongoSalesService, profiles *MongoProfileService, curb *MongoCurbAlertService) *PendingImageReferences {
+	return &PendingImageReferences{
+		sales:    sales,
+		profiles: profiles,
+		curb:     curb,
+	}
+}
+
+// Approve points references to pendingPath at approvedURL.
+func (p *PendingImageReferences) Approve(ctx context.Context, uploadType, pendingPath, approvedURL string) error {
+	switch {
+	case uploadType == UploadTypeSaleCover && p.sales != nil:
+		return p.sales.ApprovePendingSaleCover(ctx, pendingPath, approvedURL)
+	case uploadType == UploadTypeSaleItem && p.sales != nil:
+		return p.sales.ApprovePendingItemImage(ctx, pendingPath, approvedURL)
+	case uploadType == UploadTypeProfilePhoto && p.profiles != nil:
+		return p.profiles.ApprovePendingProfilePhoto(ctx, pendingPath, approvedURL)
+	case uploadType == UploadTypeCurbAlert && p.curb != nil:
+		return p.curb.ApprovePendingCurbPhoto(ctx, pendingPath, approvedURL)
+	}
+	return fmt.Errorf("%w %q", ErrNoImageReferences, uploadType)
+}
+
+// Reject clears references to pendingPath.
+func (p *PendingImageReferences) Reject(ctx context.Context, uploadType, pendingPath string) error {
+	switch {
+	case uploadType == UploadTypeSaleCover && p.sales != nil:
+		return p.sales.RejectPendingSaleCover(ctx, pendingPath)
+	case uploadType == UploadTypeSaleItem && p.sales != nil:
+		return p.sales.RejectPendingItemImage(ctx, pendingPath)
+	case uploadType == UploadTypeProfilePhoto && p.profiles != nil:
+		return p.profiles.RejectPendingProfilePhoto(ctx, pendingPath)
+	case uploadType == UploadTypeCurbAlert && p.curb != nil:
+		return p.curb.RejectPendingCurbPhoto(ctx, pendingPath)
+	}
+	return fmt.Errorf("%w %q", ErrNoImageReferences, uploadType)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrEventInProgress        = errors.New("event is being processed by another delivery")
+	ErrProcessedEventNotFound = errors.New("processed event not found")
+	ErrEventLeaseLost         = errors.New("event lease is held by another delivery")
+)
+
+// ProcessedEventStore makes storage event handling idempotent. Eventarc redelivers
+// events after errors and sometimes delivers them twice; each delivery claims the
+// event first, and replays of finished events are skipped.
+type ProcessedEventStore interface {
+	// Begin claims the event for lease. It returns the stored record, which is
+	// models.ProcessedEventDone for an event that was already handled, or
+	// ErrEventInProgress while another delivery holds an unexpired claim. A new claim
+	// gets a fresh LeaseID.
+	Begin(bucket, name, generation string, lease time.Duration) (*models.ProcessedEvent, error)
+	// SaveDecision stores the moderation decision so retries carry it out rather
+	// than classifying again.
+	SaveDecision(key, leaseID, decisionID, outcome, rule string) error
+	// Complete marks the event done.
+	Complete(key, leaseID string) error
+	// Release gives up the claim after a failure so the redelivery can start at once.
+	Release(key, leaseID string) error
+	// SaveDecision, Complete and Release only act while leaseID still holds the
+	// claim; once another delivery has taken it over they return ErrEventLeaseLost.
+}
+
+// ProcessedEventKey identifies a storage event by bucket, object and generation.
+func ProcessedEventKey(bucket, name, generation string) string {
+	return bucket + "/" + name + "#" + generation
+}
@@ -2,87 +2,85 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strings"
 	"time"
 )
 
+const recaptchaEndpoint = "https://www.google.com/recaptcha/api/siteverify"
+
+// RecaptchaVerifier verifies reCAPTCHA v2 checkbox tokens.
 type RecaptchaVerifier struct {
 	Secret     string
 	HTTPClient *http.Client
 	Endpoint   string
 }
 
-type recaptchaVerifyResponse struct {
-	Success    bool      `json:"success"`
-	ChallengeT time.Time `json:"challenge_ts"`
-	Hostname   string    `json:"hostname"`
-	ErrorCodes []string  `json:"error-codes"`
-}
-
 func NewRecaptchaVerifier(secret string) *RecaptchaVerifier {
 	return &RecaptchaVerifier{
 		Secret:   secret,
-		Endpoint: "https://www.google.com/recaptcha/api/siteverify",
+		Endpoint: recaptchaEndpoint,
 		HTTPClient: &http.Client{
 			Timeout: 8 * time.Second,
 		},
 	}
 }
 
+func (v *RecaptchaVerifier) Verify(ctx context.Context, token string, remoteIP string) (bool, string, error) {
+	return v.VerifyV2(ctx, token, remoteIP)
+}
+
 // VerifyV2 verifies a reCAPTCHA v2 checkbox token. Returns (ok, reason, error).
 func (v *RecaptchaVerifier) VerifyV2(ctx context.Context, token string, remoteIP string) (bool, string, error) {
 	if v == nil {
 		return false, "verifier_not_configured", nil
 	}
-	if strings.TrimSpace(v.Secret) == "" {
-		return false, "missing_secret", nil
-	}
-	tok := strings.TrimSpace(token)
-	if tok == "" {
-		return false, "missing_token", nil
-	}
-
-	form := url.Values{}
-	form.Set("secret", v.Secret)
-	form.Set("response", tok)
-	if strings.TrimSpace(remoteIP) != "" {
-		form.Set("remoteip", strings.TrimSpace(remoteIP))
+	_, reason, err := siteVerify(ctx, v.HTTPClient, v.Endpoint, v.Secret, token, remoteIP)
+	if err != nil || reason != "" {
+		return false, reason, err
 	}
+	return true, "", nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.Endpoint, strings.NewReader(form.Encode()))
-	if err != nil {
-		return false, "", err
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+// RecaptchaV3Verifier verifies invisible reCAPTCHA v3 tokens. A token passes only if
+// its score is at least MinScore and, when Action is set, it was issued for that action.
+type RecaptchaV3Verifier struct {
+	Secret     string
+	MinScore   float64
+	Action     string
+	HTTPClient *http.Client
+	Endpoint   string
+}
 
-	client := v.HTTPClient
-	if client == nil {
-		client = &http.Client{Timeout: 8 * time.Second}
+func NewRecaptchaV3Verifier(secret string, minScore float64, action string) *RecaptchaV3Verifier {
+	return &RecaptchaV3Verifier{
+		Secret:   secret,
+		MinScore: minScore,
+		Action:   action,
+		Endpoint: recaptchaEndpoint,
+		HTTPClient: &http.Client{
+			Timeout: 8 * time.Second,
+		},
 	}
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return false, "", err
+func (v *RecaptchaV3Verifier) Verify(ctx context.Context, token string, remoteIP string) (bool, string, error) {
+	if v == nil {
+		return false, "verifier_not_configured", nil
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return false, "", fmt.Errorf("recaptcha verify http %d", resp.StatusCode)
+	out, reason, err := siteVerify(ctx, v.HTTPClient, v.Endpoint, v.Secret, token, remoteIP)
+	if err != nil || reason != "" {
+		return false, reason, err
 	}
-
-	var out recaptchaVerifyResponse
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return false, "", err
+	if v.Action != "" && out.Action != v.Action {
+		return false, "action_mismatch", nil
 	}
-	if out.Success {
-		return true, "", nil
+	if out.Score == nil {
+		// A v2 token was sent to a v3 key.
+		return false, "missing_score", nil
 	}
-	if len(out.ErrorCodes) > 0 {
-		return false, strings.Join(out.ErrorCodes, ","), nil
+	if *out.Score < v.MinScore {
+		return false, fmt.Sprintf("low_score:%.1f", *out.Score), nil
 	}
-	return false, "verification_failed", nil
+	return true, "", nil
 }
-
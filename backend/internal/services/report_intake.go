@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+// ReportIntake files user reports. Once a target has been reported by threshold
+// different users it is hidden and queued for human review, so a scam listing
+// comes down before a reviewer gets to it.
+type ReportIntake struct {
+	reports       ReportService
+	queue         ModerationQueueService
+	sales         SalesService
+	profiles      *MongoProfileService
+	notifications NotificationPublisher
+	threshold     int
+}
+
+// NewReportIntake wires report handling. A threshold of zero or less never hides
+// content automatically; reports are still stored for reviewers.
+func NewReportIntake(reports ReportService, queue ModerationQueueService, sales SalesService, profiles *MongoProfileService, notifications NotificationPublisher, threshold int) *ReportIntake {
+	return &ReportIntake{
+		reports:       reports,
+		queue:         queue,
+		sales:         sales,
+		profiles:      profiles,
+		notifications: notifications,
+		threshold:     threshold,
+	}
+}
+
+// File stores reporterID's report and acknowledges it. It returns ErrReportExists if
+// the user already reported the target, ErrReportSelf for their own content and
+// ErrReportTargetNotFound if the target doesn't exist.
+func (ri *ReportIntake) File(ctx context.Context, reporterID string, req *models.CreateReportRequest) (*models.Report, error) {
+	targetID, ownerID, err := ri.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID == reporterID {
+		return nil, ErrReportSelf
+	}
+
+	report, err := ri.reports.Create(&models.Report{
+		ReporterID: reporterID,
+		TargetType: req.TargetType,
+		TargetID:   targetID,
+		OwnerID:    ownerID,
+		Reason:     req.Reason,
+		Note:       req.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[reports] %s %s reported by %s reason=%s", report.TargetType, report.TargetID, reporterID, report.Reason)
+
+	ri.escalate(ctx, report)
+	ri.acknowledge(ctx, report)
+	return report, nil
+}
+
+// resolve checks the target exists and returns its moderation target ID and owner.
+func (ri *ReportIntake) resolve(ctx context.Context, req *models.CreateReportRequest) (targetID, ownerID string, err error) {
+	switch req.TargetType {
+	case models.ModerationTargetSale, models.ModerationTargetItem:
+		saleID := req.TargetID
+		if req.TargetType == models.ModerationTargetItem {
+			saleID = req.SaleID
+		}
+		sale, err := ri.sales.GetByID(saleID)
+		if err == ErrSaleNotFound {
+			return "", "", ErrReportTargetNotFound
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if req.TargetType == models.ModerationTargetSale {
+			return sale.ID, sale.UserID, nil
+		}
+		for _, item := range sale.Items {
+			if item.ID == req.TargetID {
+				return models.ItemTargetID(sale.ID, item.ID), sale.UserID, nil
+			}
+		}
+		return "", "", ErrReportTargetNotFound
+	case models.ModerationTargetProfile:
+		if ri.profiles == nil {
+			return "", "", ErrReportTargetNotFound
+		}
+		prof, err := ri.profiles.GetByUserID(ctx, req.TargetID)
+		if err == mongo.ErrNoDocuments {
+			return "", "", ErrReportTargetNotFound
+		}
+		if err != nil {
+			return "", "", err
+		}
+		return prof.UserID, prof.UserID, nil
+	}
+	return "", "", ErrModerationBadInput
+}
+
+// escalate hides and queues the target once it has threshold reports since a
+// reviewer last ruled on it, so content a reviewer approved stays up until it is
+// reported that many times again. Every report past the threshold repeats the hide
+// and enqueue, which are both idempotent, so a lost race or a failed attempt is
+// made good by the next report.
+func (ri *ReportIntake) escalate(ctx context.Context, report *models.Report) {
+	if ri.threshold <= 0 {
+		return
+	}
+	var since time.Time
+	last, err := ri.queue.LastDecided(report.TargetType, report.TargetID)
+	switch {
+	case err == nil && last.DecidedAt != nil:
+		since = *last.DecidedAt
+	case err != nil && err != ErrModerationItemNotFound:
+		log.Printf("[reports] last decision %s %s failed: %v", report.TargetType, report.TargetID, err)
+		return
+	}
+	count, err := ri.reports.CountByTarget(report.TargetType, report.TargetID, since)
+	if err != nil {
+		log.Printf("[reports] count %s %s failed: %v", report.TargetType, report.TargetID, err)
+		return
+	}
+	if count < ri.threshold {
+		return
+	}
+
+	switch report.TargetType {
+	case models.ModerationTargetSale:
+		err = ri.sales.SetHidden(report.TargetID, true)
+	case models.ModerationTargetItem:
+		saleID, itemID, _ := models.SplitItemTargetID(report.TargetID)
+		err = ri.sales.SetItemHidden(saleID, itemID, true)
+	case models.ModerationTargetProfile:
+		err = ri.profiles.SetHidden(ctx, report.TargetID, true)
+	}
+	if err != nil {
+		log.Printf("[reports] hide %s %s failed: %v", report.TargetType, report.TargetID, err)
+		return
+	}
+
+	rule, reason := ri.summarize(report, since)
+	if _, err := ri.queue.Enqueue(&models.ModerationQueueItem{
+		TargetType: report.TargetType,
+		TargetID:   report.TargetID,
+		UserID:     report.OwnerID,
+		Source:     models.ModerationSourceReport,
+		Rule:       rule,
+		Reason:     reason,
+	}); err != nil {
+		log.Printf("[reports] enqueue %s %s failed: %v", report.TargetType, report.TargetID, err)
+		return
+	}
+	log.Printf("[reports] %s %s hidden for review after %d reports", report.TargetType, report.TargetID, count)
+}
+
+// summarize names the most common reason among the reports after since as the
+// queue rule ("report:scam") and lists the reason counts for the reviewer
+// ("3 reports: scam x2, spam x1").
+func (ri *ReportIntake) summarize(report *models.Report, since time.Time) (rule, reason string) {
+	counts := make(map[string]int)
+	if all, err := ri.reports.ListByTarget(report.TargetType, report.TargetID, 0); err == nil {
+		for _, r := range all {
+			if r.CreatedAt.After(since) {
+				counts[r.Reason]++
+			}
+		}
+	}
+	if len(counts) == 0 {
+		counts[report.Reason] = 1
+	}
+
+	reasons := make([]string, 0, len(counts))
+	total := 0
+	for r, n := range counts {
+		reasons = append(reasons, r)
+		total += n
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if counts[reasons[i]] != counts[reasons[j]] {
+			return counts[reasons[i]] > counts[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	parts := make([]string, len(reasons))
+	for i, r := range reasons {
+		parts[i] = fmt.Sprintf("%s x%d", r, counts[r])
+	}
+	return "report:" + reasons[0], fmt.Sprintf("%d reports: %s", total, strings.Join(parts, ", "))
+}
+
+func (ri *ReportIntake) acknowledge(ctx context.Context, report *models.Report) {
+	if ri.notifications == nil {
+		return
+	}
+	n := &models.Notification{
+		UserID: report.ReporterID,
+		Type:   models.NotificationReportReceived,
+		Title:  "Thanks for your report",
+		Body:   ReportAcknowledgement(report.TargetType),
+		Data:   map[string]string{"report_id": report.ID, "target_type": report.TargetType, "target_id": report.TargetID},
+	}
+	if err := ri.notifications.Publish(ctx, n); err != nil {
+		log.Printf("[reports] acknowledge failed reporterID=%s err=%v", report.ReporterID, err)
+	}
+}
+
+// ReportAcknowledgement is the message reporters see once their report is filed.
+func ReportAcknowledgement(targetType string) string {
+	return fmt.Sprintf("We received your report about this %s. Our team will review it and take action if it breaks our community guidelines.", targetType)
+}
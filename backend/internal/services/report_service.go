@@ -0,0 +1,26 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrReportExists         = errors.New("already reported")
+	ErrReportTargetNotFound = errors.New("reported content not found")
+	ErrReportSelf           = errors.New("cannot report your own content")
+)
+
+// ReportService stores users' reports of sales, items and profiles.
+type ReportService interface {
+	// Create stores a report. Each user can report a target once; a second report
+	// returns ErrReportExists.
+	Create(report *models.Report) (*models.Report, error)
+	// CountByTarget returns how many users have reported the target after since; a
+	// zero since counts every report.
+	CountByTarget(targetType, targetID string, since time.Time) (int, error)
+	// ListByTarget returns the target's reports, oldest first.
+	ListByTarget(targetType, targetID string, limit int) ([]*models.Report, error)
+}
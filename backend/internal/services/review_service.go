@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrReviewNotFound     = errors.New("review not found")
+	ErrAlreadyReviewed    = errors.New("sale already reviewed")
+	ErrReviewTooEarly     = errors.New("sale has not started yet")
+	ErrReviewOwnSale      = errors.New("cannot review your own sale")
+	ErrReviewReplyDenied  = errors.New("only the seller can reply to a review")
+	ErrReviewBadInput     = errors.New("bad input")
+	ErrReviewSaleNotFound = errors.New("sale not found")
+)
+
+// ReviewService is used by handlers; production uses Mongo-backed implementation.
+type ReviewService interface {
+	CreateReview(userID, saleID string, req *models.CreateReviewRequest) (*models.Review, error)
+	// ListSaleReviews returns reviews for a sale, newest first. Hidden reviews are
+	// left out and hidden replies removed.
+	ListSaleReviews(saleID string) ([]*models.Review, error)
+	// ListSellerReviews returns reviews across all of a seller's sales, newest first,
+	// filtered like ListSaleReviews.
+	ListSellerReviews(sellerID string, limit int) ([]*models.Review, error)
+	ReplyToReview(userID, saleID, reviewID string, req *models.ReplyReviewRequest) (*models.Review, error)
+	GetSellerRating(sellerID string) (*models.SellerRating, error)
+	// CanReview and CanReply run the access checks of CreateReview and ReplyToReview
+	// without writing, returning the same errors.
+	CanReview(userID, saleID string) error
+	CanReply(userID, saleID, reviewID string) error
+	// SetHidden hides or restores a review; SetReplyHidden does the same for the
+	// seller's reply only. Both are used by moderation.
+	SetHidden(reviewID string, hidden bool) error
+	SetReplyHidden(reviewID string, hidden bool) error
+}
+
+// saleOpenForReview reports whether buyers may review the sale yet. A sale counts as
+// started once the seller has gone live or its scheduled start date has passed.
+func saleOpenForReview(sale *models.GarageSale, now time.Time) bool {
+	if sale.IsActive {
+		return true
+	}
+	return !sale.StartDate.IsZero() && !sale.StartDate.After(now)
+}
@@ -2,38 +2,66 @@ package services
 
 import (
 	"context"
+	"fmt"
 
 	"google.golang.org/api/option"
 	vision "google.golang.org/api/vision/v1"
 )
 
 type SafeSearchResult struct {
-	Adult    string
-	Violence string
-	Racy     string
-	Spoof    string
-	Medical  string
+	Adult    string `json:"adult"`
+	Violence string `json:"violence"`
+	Racy     string `json:"racy"`
+	Spoof    string `json:"spoof"`
+	Medical  string `json:"medical"`
 }
 
-// DetectSafeSearch runs Vision SAFE_SEARCH_DETECTION on a GCS URI.
-// Ref: https://docs.cloud.google.com/vision/docs/detecting-safe-search#vision_safe_search_detection_gcs-go
-func DetectSafeSearch(ctx context.Context, gcsURI string) (*SafeSearchResult, error) {
-	// Uses Application Default Credentials in Cloud Run.
+// field returns a pointer to the named category's likelihood, or nil.
+func (r *SafeSearchResult) field(category string) *string {
+	switch category {
+	case "adult":
+		return &r.Adult
+	case "violence":
+		return &r.Violence
+	case "racy":
+		return &r.Racy
+	case "spoof":
+		return &r.Spoof
+	case "medical":
+		return &r.Medical
+	}
+	return nil
+}
+
+// VisionClassifier runs Cloud Vision SAFE_SEARCH_DETECTION on objects in GCS. The
+// Vision client is created once and shared across calls.
+type VisionClassifier struct {
+	svc *vision.Service
+}
+
+// NewVisionClassifier uses Application Default Credentials (the service account on
+// Cloud Run).
+func NewVisionClassifier(ctx context.Context) (*VisionClassifier, error) {
 	svc, err := vision.NewService(ctx, option.WithScopes(vision.CloudPlatformScope))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("vision: new service: %w", err)
 	}
+	return &VisionClassifier{svc: svc}, nil
+}
 
+// Classify runs SafeSearch on the object.
+// Ref: https://docs.cloud.google.com/vision/docs/detecting-safe-search#vision_safe_search_detection_gcs-go
+func (v *VisionClassifier) Classify(ctx context.Context, img ImageRef) (*SafeSearchResult, error) {
 	req := &vision.AnnotateImageRequest{
 		Image: &vision.Image{
-			Source: &vision.ImageSource{GcsImageUri: gcsURI},
+			Source: &vision.ImageSource{GcsImageUri: img.GCSURI()},
 		},
 		Features: []*vision.Feature{
 			{Type: "SAFE_SEARCH_DETECTION"},
 		},
 	}
 
-	call := svc.Images.Annotate(&vision.BatchAnnotateImagesRequest{
+	call := v.svc.Images.Annotate(&vision.BatchAnnotateImagesRequest{
 		Requests: []*vision.AnnotateImageRequest{req},
 	})
 	resp, err := call.Context(ctx).Do()
@@ -44,6 +72,9 @@ func DetectSafeSearch(ctx context.Context, gcsURI string) (*SafeSearchResult, er
 		return &SafeSearchResult{}, nil
 	}
 	r := resp.Responses[0]
+	if r.Error != nil {
+		return nil, fmt.Errorf("vision: %s", r.Error.Message)
+	}
 	ss := r.SafeSearchAnnotation
 	if ss == nil {
 		return &SafeSearchResult{}, nil
@@ -57,12 +88,3 @@ func DetectSafeSearch(ctx context.Context, gcsURI string) (*SafeSearchResult, er
 		Medical:  ss.Medical,
 	}, nil
 }
-
-func isUnsafeLikelyOrHigher(l string) bool {
-	return l == "LIKELY" || l == "VERY_LIKELY"
-}
-
-func (r *SafeSearchResult) IsUnsafe() bool {
-	return isUnsafeLikelyOrHigher(r.Adult) || isUnsafeLikelyOrHigher(r.Violence) || isUnsafeLikelyOrHigher(r.Racy)
-}
-
@@ -19,6 +19,12 @@ var (
 	ErrSaleNotFound = errors.New("sale not found")
 	ErrItemNotFound = errors.New("item not found")
 	ErrUnauthorized = errors.New("unauthorized to modify this sale")
+
+	ErrCoHostNotFound = errors.New("co-host not found")
+	ErrCoHostExists   = errors.New("user is already a co-host of this sale")
+	ErrCoHostLimit    = errors.New("co-host limit reached")
+	ErrCoHostSelf     = errors.New("the owner cannot be a co-host")
+	ErrCoHostConflict = errors.New("co-hosts changed concurrently")
 )
 
 // SalesService is the interface used by handlers. Implementations may be file-based
@@ -33,12 +39,34 @@ type SalesService interface {
 	EndSale(userID, saleID string) (*models.GarageSale, error)
 	// ListByUser returns sales created by the given user, sorted by created_at desc.
 	ListByUser(userID string, limit int) ([]*models.GarageSale, error)
+	// ListUpcomingByUsers returns sales by any of the given users that have not ended yet,
+	// soonest start first.
+	ListUpcomingByUsers(userIDs []string, limit int) ([]*models.GarageSale, error)
 	ListNearby(lat, lng, radiusMi float64) ([]*models.GarageSale, error)
 	SearchNearby(lat, lng, radiusMi float64, q string) ([]*models.GarageSale, error)
 	ListByBounds(minLat, maxLat, minLng, maxLng float64, limit int) ([]*models.GarageSale, error)
 	AddItem(userID, saleID string, req *models.CreateItemRequest) (*models.Item, error)
 	UpdateItem(userID, saleID, itemID string, req *models.UpdateItemRequest) (*models.Item, error)
 	DeleteItem(userID, saleID, itemID string) error
+
+	// InviteCoHost lets the owner invite another user with a role. The invitee has no
+	// access until they accept; inviting an existing co-host changes their role.
+	InviteCoHost(ownerID, saleID string, req *models.InviteCoHostRequest) (*models.GarageSale, error)
+	// AcceptCoHostInvite accepts the caller's pending invite.
+	AcceptCoHostInvite(userID, saleID string) (*models.GarageSale, error)
+	// RemoveCoHost removes a co-host. The owner can remove anyone; a co-host can only
+	// remove themselves (declining an invite or leaving).
+	RemoveCoHost(userID, saleID, coHostID string) (*models.GarageSale, error)
+	// ListCoHostedByUser returns sales the user co-hosts or is invited to, sorted by
+	// created_at desc.
+	ListCoHostedByUser(userID string, limit int) ([]*models.GarageSale, error)
+
+	// SetHidden hides or restores a sale for moderation. It is not an owner action, so
+	// there is no user check.
+	SetHidden(saleID string, hidden bool) error
+	// SetItemHidden hides or restores a single item. Hidden items are left out of
+	// discovery results but still returned to the sale's owner and co-hosts.
+	SetItemHidden(saleID, itemID string, hidden bool) error
 }
 
 // SalesData represents the persisted sales data structure
@@ -142,7 +170,7 @@ func (s *FileSalesService) GetByID(id string) (*models.GarageSale, error) {
 
 	// Attach items
 	saleCopy := *sale
-	saleCopy.Items = s.getItemsForSale(id)
+	saleCopy.Items = s.getItemsForSale(id, true)
 
 	return &saleCopy, nil
 }
@@ -156,7 +184,7 @@ func (s *FileSalesService) Update(userID, saleID string, req *models.UpdateSaleR
 		return nil, ErrSaleNotFound
 	}
 
-	if sale.UserID != userID {
+	if !sale.CanUser(userID, models.SaleActionEdit) {
 		return nil, ErrUnauthorized
 	}
 
@@ -180,7 +208,7 @@ func (s *FileSalesService) SetSaleCoverPhoto(userID, saleID, coverURL string) (*
 	if !exists {
 		return nil, ErrSaleNotFound
 	}
-	if sale.UserID != userID {
+	if !sale.CanUser(userID, models.SaleActionEdit) {
 		return nil, ErrUnauthorized
 	}
 
@@ -189,6 +217,32 @@ func (s *FileSalesService) SetSaleCoverPhoto(userID, saleID, coverURL string) (*
 	return sale, nil
 }
 
+func (s *FileSalesService) SetHidden(saleID string, hidden bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sale, exists := s.sales[saleID]
+	if !exists {
+		return ErrSaleNotFound
+	}
+	sale.Hidden = hidden
+	s.saveToStore()
+	return nil
+}
+
+func (s *FileSalesService) SetItemHidden(saleID, itemID string, hidden bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, exists := s.items[itemID]
+	if !exists || item.SaleID != saleID {
+		return ErrItemNotFound
+	}
+	item.Hidden = hidden
+	s.saveToStore()
+	return nil
+}
+
 func (s *FileSalesService) Delete(userID, saleID string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -198,7 +252,7 @@ func (s *FileSalesService) Delete(userID, saleID string) error {
 		return ErrSaleNotFound
 	}
 
-	if sale.UserID != userID {
+	if !sale.CanUser(userID, models.SaleActionManage) {
 		return ErrUnauthorized
 	}
 
@@ -223,7 +277,7 @@ func (s *FileSalesService) StartSale(userID, saleID string) (*models.GarageSale,
 		return nil, ErrSaleNotFound
 	}
 
-	if sale.UserID != userID {
+	if !sale.CanUser(userID, models.SaleActionRun) {
 		return nil, ErrUnauthorized
 	}
 
@@ -241,7 +295,7 @@ func (s *FileSalesService) EndSale(userID, saleID string) (*models.GarageSale, e
 		return nil, ErrSaleNotFound
 	}
 
-	if sale.UserID != userID {
+	if !sale.CanUser(userID, models.SaleActionRun) {
 		return nil, ErrUnauthorized
 	}
 
@@ -267,7 +321,7 @@ func (s *FileSalesService) ListByUser(userID string, limit int) ([]*models.Garag
 			continue
 		}
 		copy := *sale
-		copy.Items = s.getItemsForSale(sale.ID)
+		copy.Items = s.getItemsForSale(sale.ID, true)
 		results = append(results, &copy)
 	}
 
@@ -281,6 +335,40 @@ func (s *FileSalesService) ListByUser(userID string, limit int) ([]*models.Garag
 	return results, nil
 }
 
+func (s *FileSalesService) ListUpcomingByUsers(userIDs []string, limit int) ([]*models.GarageSale, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if limit <= 0 || limit > 500 {
+		limit = 500
+	}
+
+	wanted := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = true
+	}
+
+	now := time.Now()
+	results := make([]*models.GarageSale, 0)
+	for _, sale := range s.sales {
+		if !wanted[sale.UserID] || sale.EndDate.Before(now) || sale.Hidden {
+			continue
+		}
+		copy := *sale
+		copy.Items = s.getItemsForSale(sale.ID, false)
+		results = append(results, &copy)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].StartDate.Before(results[j].StartDate)
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
 func (s *FileSalesService) ListNearby(lat, lng, radiusMi float64) ([]*models.GarageSale, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -289,9 +377,9 @@ func (s *FileSalesService) ListNearby(lat, lng, radiusMi float64) ([]*models.Gar
 
 	for _, sale := range s.sales {
 		distance := haversineDistance(lat, lng, sale.Latitude, sale.Longitude)
-		if distance <= radiusMi {
+		if distance <= radiusMi && !sale.Hidden {
 			saleCopy := *sale
-			saleCopy.Items = s.getItemsForSale(sale.ID)
+			saleCopy.Items = s.getItemsForSale(sale.ID, false)
 			results = append(results, &saleCopy)
 		}
 	}
@@ -305,27 +393,14 @@ func (s *FileSalesService) SearchNearby(lat, lng, radiusMi float64, q string) ([
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if radiusMi <= 0 {
-		radiusMi = 10
-	}
-	q = strings.ToLower(strings.TrimSpace(q))
-
 	results := make([]*models.GarageSale, 0)
 	for _, sale := range s.sales {
-		distance := haversineDistance(lat, lng, sale.Latitude, sale.Longitude)
-		if distance > radiusMi {
+		if sale.Hidden || !saleMatchesSearch(sale, lat, lng, radiusMi, q) {
 			continue
 		}
 
-		if q != "" {
-			blob := strings.ToLower(sale.Title + " " + sale.Description + " " + sale.Address)
-			if !strings.Contains(blob, q) {
-				continue
-			}
-		}
-
 		saleCopy := *sale
-		saleCopy.Items = s.getItemsForSale(sale.ID)
+		saleCopy.Items = s.getItemsForSale(sale.ID, false)
 		results = append(results, &saleCopy)
 	}
 
@@ -345,9 +420,9 @@ func (s *FileSalesService) ListByBounds(minLat, maxLat, minLng, maxLng float64,
 
 	for _, sale := range s.sales {
 		if sale.Latitude >= minLat && sale.Latitude <= maxLat &&
-			sale.Longitude >= minLng && sale.Longitude <= maxLng {
+			sale.Longitude >= minLng && sale.Longitude <= maxLng && !sale.Hidden {
 			saleCopy := *sale
-			saleCopy.Items = s.getItemsForSale(sale.ID)
+			saleCopy.Items = s.getItemsForSale(sale.ID, false)
 			results = append(results, &saleCopy)
 		}
 	}
@@ -373,7 +448,7 @@ func (s *FileSalesService) AddItem(userID, saleID string, req *models.CreateItem
 		return nil, ErrSaleNotFound
 	}
 
-	if sale.UserID != userID {
+	if !sale.CanUser(userID, models.SaleActionEdit) {
 		return nil, ErrUnauthorized
 	}
 
@@ -406,7 +481,7 @@ func (s *FileSalesService) UpdateItem(userID, saleID, itemID string, req *models
 	if !exists {
 		return nil, ErrSaleNotFound
 	}
-	if sale.UserID != userID {
+	if !sale.CanUser(userID, models.SaleActionEdit) {
 		return nil, ErrUnauthorized
 	}
 
@@ -439,7 +514,7 @@ func (s *FileSalesService) DeleteItem(userID, saleID, itemID string) error {
 		return ErrSaleNotFound
 	}
 
-	if sale.UserID != userID {
+	if !sale.CanUser(userID, models.SaleActionEdit) {
 		return ErrUnauthorized
 	}
 
@@ -453,16 +528,111 @@ func (s *FileSalesService) DeleteItem(userID, saleID, itemID string) error {
 	return nil
 }
 
-func (s *FileSalesService) getItemsForSale(saleID string) []models.Item {
+func (s *FileSalesService) InviteCoHost(ownerID, saleID string, req *models.InviteCoHostRequest) (*models.GarageSale, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sale, exists := s.sales[saleID]
+	if !exists {
+		return nil, ErrSaleNotFound
+	}
+	if err := inviteCoHost(sale, ownerID, req, time.Now()); err != nil {
+		return nil, err
+	}
+
+	s.saveToStore()
+	return sale, nil
+}
+
+func (s *FileSalesService) AcceptCoHostInvite(userID, saleID string) (*models.GarageSale, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sale, exists := s.sales[saleID]
+	if !exists {
+		return nil, ErrSaleNotFound
+	}
+	if err := acceptCoHostInvite(sale, userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	s.saveToStore()
+	return sale, nil
+}
+
+func (s *FileSalesService) RemoveCoHost(userID, saleID, coHostID string) (*models.GarageSale, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sale, exists := s.sales[saleID]
+	if !exists {
+		return nil, ErrSaleNotFound
+	}
+	if err := removeCoHost(sale, userID, coHostID); err != nil {
+		return nil, err
+	}
+
+	s.saveToStore()
+	return sale, nil
+}
+
+func (s *FileSalesService) ListCoHostedByUser(userID string, limit int) ([]*models.GarageSale, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if limit <= 0 || limit > 500 {
+		limit = 500
+	}
+
+	results := make([]*models.GarageSale, 0)
+	for _, sale := range s.sales {
+		if sale.CoHost(userID) == nil {
+			continue
+		}
+		copy := *sale
+		copy.Items = s.getItemsForSale(sale.ID, true)
+		results = append(results, &copy)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func (s *FileSalesService) getItemsForSale(saleID string, includeHidden bool) []models.Item {
 	var items []models.Item
 	for _, item := range s.items {
-		if item.SaleID == saleID {
+		if item.SaleID == saleID && (includeHidden || !item.Hidden) {
 			items = append(items, *item)
 		}
 	}
 	return items
 }
 
+// saleMatchesSearch applies the SearchNearby filter to a single sale: the sale must be
+// within radiusMi of (lat, lng) and, when q is set, q must appear in its title,
+// description or address (case-insensitive).
+func saleMatchesSearch(sale *models.GarageSale, lat, lng, radiusMi float64, q string) bool {
+	if radiusMi <= 0 {
+		radiusMi = 10
+	}
+	if haversineDistance(lat, lng, sale.Latitude, sale.Longitude) > radiusMi {
+		return false
+	}
+
+	q = strings.ToLower(strings.TrimSpace(q))
+	if q == "" {
+		return true
+	}
+	blob := strings.ToLower(sale.Title + " " + sale.Description + " " + sale.Address)
+	return strings.Contains(blob, q)
+}
+
 // haversineDistance calculates distance between two points in miles
 func haversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	const earthRadiusMiles = 3959.0
@@ -479,3 +649,61 @@ func haversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
 
 	return earthRadiusMiles * c
 }
+
+// inviteCoHost adds or re-roles a co-host on the sale. Only the owner may invite.
+func inviteCoHost(sale *models.GarageSale, ownerID string, req *models.InviteCoHostRequest, now time.Time) error {
+	if !sale.CanUser(ownerID, models.SaleActionManage) {
+		return ErrUnauthorized
+	}
+	if req.UserID == sale.UserID {
+		return ErrCoHostSelf
+	}
+	if existing := sale.CoHost(req.UserID); existing != nil {
+		if existing.Role == req.Role {
+			return ErrCoHostExists
+		}
+		existing.Role = req.Role
+		return nil
+	}
+	if len(sale.CoHosts) >= models.MaxSaleCoHosts {
+		return ErrCoHostLimit
+	}
+	sale.CoHosts = append(sale.CoHosts, models.SaleCoHost{
+		UserID:    req.UserID,
+		Role:      req.Role,
+		Status:    models.CoHostStatusInvited,
+		InvitedAt: now,
+	})
+	return nil
+}
+
+// acceptCoHostInvite accepts userID's invite; accepting twice is a no-op.
+func acceptCoHostInvite(sale *models.GarageSale, userID string, now time.Time) error {
+	c := sale.CoHost(userID)
+	if c == nil {
+		return ErrCoHostNotFound
+	}
+	if c.Status != models.CoHostStatusAccepted {
+		c.Status = models.CoHostStatusAccepted
+		c.AcceptedAt = &now
+	}
+	return nil
+}
+
+// removeCoHost drops coHostID from the sale if userID is the owner or coHostID itself.
+func removeCoHost(sale *models.GarageSale, userID, coHostID string) error {
+	if userID != coHostID && !sale.CanUser(userID, models.SaleActionManage) {
+		return ErrUnauthorized
+	}
+	kept := make([]models.SaleCoHost, 0, len(sale.CoHosts))
+	for _, c := range sale.CoHosts {
+		if c.UserID != coHostID {
+			kept = append(kept, c)
+		}
+	}
+	if len(kept) == len(sale.CoHosts) {
+		return ErrCoHostNotFound
+	}
+	sale.CoHosts = kept
+	return nil
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrSavedSearchNotFound = errors.New("saved search not found")
+	ErrSavedSearchLimit    = errors.New("saved search limit reached")
+	ErrSavedSearchBadInput = errors.New("bad input")
+)
+
+// maxSavedSearchesPerUser caps how many searches one user can save.
+const maxSavedSearchesPerUser = 25
+
+// SavedSearchService is used by handlers; production uses Mongo-backed implementation.
+type SavedSearchService interface {
+	CreateSavedSearch(userID string, req *models.CreateSavedSearchRequest) (*models.SavedSearch, error)
+	ListSavedSearches(userID string) ([]*models.SavedSearch, error)
+	DeleteSavedSearch(userID, searchID string) error
+	// ListAlerts returns the user's saved-search alerts, most recent first.
+	ListAlerts(userID string, limit int) ([]*models.SavedSearchAlert, error)
+}
+
+// savedSearchMatches reports whether a newly created sale, or an item newly added to
+// it, satisfies the saved search. Hidden sales and items never match. Location and
+// query use the SearchNearby semantics (see textSearchMatches), so a bare sale only
+// matches a search with a query; the query may also match the item. Category and price
+// filters only apply to items, so a search that sets them never matches a bare sale,
+// and an item matches a search without a query on those filters alone.
+func savedSearchMatches(search *models.SavedSearch, sale *models.GarageSale, item *models.Item) bool {
+	if sale.Hidden || (item != nil && item.Hidden) {
+		return false
+	}
+	if !saleMatchesSearch(sale, search.Latitude, search.Longitude, search.RadiusMi, "") {
+		return false
+	}
+	saleText := sale.Title + " " + sale.Description + " " + sale.Address
+	hasItemFilters := len(search.Categories) > 0 || search.MinPrice != nil || search.MaxPrice != nil
+	if item == nil {
+		return !hasItemFilters && textSearchMatches(saleText, search.Query)
+	}
+
+	if strings.TrimSpace(search.Query) == "" {
+		if !hasItemFilters {
+			return false
+		}
+	} else if !textSearchMatches(itemSearchText(item), search.Query) && !textSearchMatches(saleText, search.Query) {
+		return false
+	}
+	if len(search.Categories) > 0 {
+		found := false
+		for _, c := range search.Categories {
+			if strings.EqualFold(c, item.Category) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if search.MinPrice != nil && item.Price < *search.MinPrice {
+		return false
+	}
+	if search.MaxPrice != nil && item.Price > *search.MaxPrice {
+		return false
+	}
+	return true
+}
+
+// itemSearchText is the lowercased text an item is matched on.
+func itemSearchText(item *models.Item) string {
+	return strings.ToLower(item.Name + " " + item.Description + " " + item.Category)
+}
+
+// textSearchMatches approximates Mongo's $text search over text. Terms match whole
+// words, ignoring case and common English suffixes, and any one term is enough.
+// Quoted phrases must all appear, and then decide the match on their own; -terms
+// exclude. A query without terms or phrases matches nothing, as in SearchNearby.
+func textSearchMatches(text, q string) bool {
+	lower := strings.ToLower(text)
+	words := make(map[string]bool)
+	for _, w := range searchWords(lower) {
+		words[stemWord(w)] = true
+	}
+
+	var terms []string
+	phrases := 0
+	for i, part := range strings.Split(strings.ToLower(q), `"`) {
+		if i%2 == 1 {
+			phrase := strings.TrimSpace(part)
+			if phrase == "" {
+				continue
+			}
+			if !strings.Contains(lower, phrase) {
+				return false
+			}
+			phrases++
+			continue
+		}
+		for _, f := range strings.Fields(part) {
+			if strings.HasPrefix(f, "-") {
+				for _, w := range searchWords(f[1:]) {
+					if words[stemWord(w)] {
+						return false
+					}
+				}
+				continue
+			}
+			terms = append(terms, searchWords(f)...)
+		}
+	}
+	if phrases > 0 {
+		return true
+	}
+	for _, t := range terms {
+		if words[stemWord(t)] {
+			return true
+		}
+	}
+	return false
+}
+
+// searchWords splits text into runs of letters and digits.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// stemWord strips the common English suffixes so that, say, "chairs" and "chair"
+// match, roughly as the text index's stemmer does.
+func stemWord(w string) string {
+	switch {
+	case len(w) > 4 && strings.HasSuffix(w, "ies"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 5 && strings.HasSuffix(w, "ing"):
+		return w[:len(w)-3]
+	case len(w) > 4 && strings.HasSuffix(w, "ed"):
+		return w[:len(w)-2]
+	case len(w) > 4 && (strings.HasSuffix(w, "ches") || strings.HasSuffix(w, "shes") || strings.HasSuffix(w, "xes") || strings.HasSuffix(w, "sses")):
+		return w[:len(w)-2]
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+		return w[:len(w)-1]
+	}
+	return w
+}
@@ -10,23 +10,20 @@ import (
 	"time"
 )
 
+// SendGridMailer sends through the SendGrid v3 mail send API.
 type SendGridMailer struct {
 	APIKey     string
 	FromEmail  string
-	ToEmail    string
+	FromName   string
 	HTTPClient *http.Client
 	Endpoint   string
 }
 
-func NewSendGridMailer(apiKey string, fromEmail string, toEmail string) *SendGridMailer {
-	to := strings.TrimSpace(toEmail)
-	if to == "" {
-		to = "support@ludicrousapps.io"
-	}
+func NewSendGridMailer(apiKey string, fromEmail string, fromName string) *SendGridMailer {
 	return &SendGridMailer{
 		APIKey:    strings.TrimSpace(apiKey),
 		FromEmail: strings.TrimSpace(fromEmail),
-		ToEmail:   to,
+		FromName:  fromName,
 		Endpoint:  "https://api.sendgrid.com/v3/mail/send",
 		HTTPClient: &http.Client{
 			Timeout: 10 * time.Second,
@@ -57,56 +54,51 @@ type sendGridMailSendRequest struct {
 	Content          []sendGridContent         `json:"content"`
 }
 
-func (m *SendGridMailer) SendSupportEmail(ctx context.Context, ticket string, userName string, userEmail string, message string) error {
+func (m *SendGridMailer) Send(ctx context.Context, msg *EmailMessage) error {
 	if m == nil {
-		return fmt.Errorf("sendgrid mailer not configured")
+		return ErrMailerNotConfigured
 	}
 	if m.APIKey == "" {
 		return fmt.Errorf("missing SENDGRID_API_KEY")
 	}
 	if m.FromEmail == "" {
-		return fmt.Errorf("missing SUPPORT_FROM_EMAIL")
+		return fmt.Errorf("missing MAIL_FROM")
 	}
-	if m.ToEmail == "" {
-		return fmt.Errorf("missing SUPPORT_TO_EMAIL")
+	if err := validateEmailMessage(msg); err != nil {
+		return err
 	}
 
-	subject := fmt.Sprintf("Support Request: #%s", ticket)
-	body := strings.TrimSpace(message)
-	if body == "" {
-		body = "(empty message)"
+	fromName := m.FromName
+	if msg.FromName != "" {
+		fromName = msg.FromName
 	}
 
-	plain := fmt.Sprintf(
-		"Support ticket: %s\nFrom: %s <%s>\n\nMessage:\n%s\n",
-		ticket,
-		strings.TrimSpace(userName),
-		strings.TrimSpace(userEmail),
-		body,
-	)
-
 	reqBody := sendGridMailSendRequest{
 		Personalizations: []sendGridPersonalization{
 			{
-				To:      []sendGridEmailAddress{{Email: m.ToEmail}},
-				Subject: subject,
-				CustomArgs: map[string]string{
-					"ticket": ticket,
-				},
+				To:         []sendGridEmailAddress{{Email: strings.TrimSpace(msg.To), Name: msg.ToName}},
+				Subject:    msg.Subject,
+				CustomArgs: msg.Tags,
 			},
 		},
 		From: sendGridEmailAddress{
 			Email: m.FromEmail,
-			Name:  "Rummage Support Form",
-		},
-		ReplyTo: &sendGridEmailAddress{
-			Email: strings.TrimSpace(userEmail),
-			Name:  strings.TrimSpace(userName),
-		},
-		Content: []sendGridContent{
-			{Type: "text/plain", Value: plain},
+			Name:  fromName,
 		},
 	}
+	if msg.ReplyTo != "" {
+		reqBody.ReplyTo = &sendGridEmailAddress{
+			Email: strings.TrimSpace(msg.ReplyTo),
+			Name:  strings.TrimSpace(msg.ReplyToName),
+		}
+	}
+	// SendGrid requires text/plain before text/html.
+	if msg.Text != "" {
+		reqBody.Content = append(reqBody.Content, sendGridContent{Type: "text/plain", Value: msg.Text})
+	}
+	if msg.HTML != "" {
+		reqBody.Content = append(reqBody.Content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
 
 	b, err := json.Marshal(reqBody)
 	if err != nil {
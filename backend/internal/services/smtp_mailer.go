@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPMailer sends through a plain SMTP relay. STARTTLS is used when the server offers
+// it; credentials are optional for relays that authenticate by IP.
+type SMTPMailer struct {
+	Host      string
+	Port      string
+	Username  string
+	Password  string
+	FromEmail string
+	FromName  string
+}
+
+func NewSMTPMailer(host, port, username, password, fromEmail, fromName string) *SMTPMailer {
+	if strings.TrimSpace(port) == "" {
+		port = "587"
+	}
+	return &SMTPMailer{
+		Host:      strings.TrimSpace(host),
+		Port:      strings.TrimSpace(port),
+		Username:  username,
+		Password:  password,
+		FromEmail: strings.TrimSpace(fromEmail),
+		FromName:  fromName,
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg *EmailMessage) error {
+	if m == nil || m.Host == "" {
+		return ErrMailerNotConfigured
+	}
+	if m.FromEmail == "" {
+		return fmt.Errorf("missing MAIL_FROM")
+	}
+	if err := validateEmailMessage(msg); err != nil {
+		return err
+	}
+
+	fromName := m.FromName
+	if msg.FromName != "" {
+		fromName = msg.FromName
+	}
+	body, err := buildMIMEMessage(m.FromEmail, fromName, msg)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	// net/smtp has no context support; run it in a goroutine so callers' deadlines hold.
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(net.JoinHostPort(m.Host, m.Port), auth, m.FromEmail, []string{msg.To}, body)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrUploadsBlocked   = errors.New("uploads blocked by moderation strikes")
+	ErrSalesBlocked     = errors.New("sale creation blocked by moderation strikes")
+	ErrAccountSuspended = errors.New("account suspended")
+)
+
+// StrikePolicy sets the enforcement tiers. Only strikes younger than DecayAfter
+// count; zero tier thresholds or DecayAfter disable that rule.
+type StrikePolicy struct {
+	// UploadBlockAt active strikes block photo uploads.
+	UploadBlockAt int
+	// SaleBlockAt active strikes block creating sales.
+	SaleBlockAt int
+	// Each strike from SuspendAt on suspends the account for SuspendFor.
+	SuspendAt  int
+	SuspendFor time.Duration
+	DecayAfter time.Duration
+}
+
+// Evaluate works out what flag's active strikes block at now.
+func (p StrikePolicy) Evaluate(flag *models.UserFlag, now time.Time) *models.UserRestrictions {
+	out := &models.UserRestrictions{}
+	if flag == nil {
+		return out
+	}
+
+	var latest time.Time
+	for _, s := range flag.StrikeLog {
+		if p.DecayAfter > 0 && !s.At.After(now.Add(-p.DecayAfter)) {
+			continue
+		}
+		out.ActiveStrikes++
+		if s.At.After(latest) {
+			latest = s.At
+		}
+	}
+
+	out.UploadsBlocked = p.UploadBlockAt > 0 && out.ActiveStrikes >= p.UploadBlockAt
+	out.SalesBlocked = p.SaleBlockAt > 0 && out.ActiveStrikes >= p.SaleBlockAt
+	if p.SuspendAt > 0 && out.ActiveStrikes >= p.SuspendAt {
+		// The most recent strike restarts the suspension.
+		until := latest.Add(p.SuspendFor)
+		if until.After(now) {
+			out.SuspendedUntil = &until
+		}
+	}
+	return out
+}
+
+// StrikeEnforcer records strikes and answers what they currently block.
+type StrikeEnforcer struct {
+	flags  *MongoUserFlagService
+	policy StrikePolicy
+}
+
+// NewStrikeEnforcer wires enforcement. flags may be nil when strike tracking is
+// unavailable; nothing is then recorded or blocked.
+func NewStrikeEnforcer(flags *MongoUserFlagService, policy StrikePolicy) *StrikeEnforcer {
+	return &StrikeEnforcer{flags: flags, policy: policy}
+}
+
+// AddStrike records a strike for userID. Failures are logged: moderation has
+// already acted by the time a strike is recorded.
+func (e *StrikeEnforcer) AddStrike(ctx context.Context, userID, reason, decisionID string) {
+	if e == nil || e.flags == nil || userID == "" {
+		return
+	}
+	flag, err := e.flags.AddStrike(ctx, userID, reason, decisionID)
+	if err != nil {
+		log.Printf("[strikes] add strike failed userID=%s err=%v", userID, err)
+		return
+	}
+	res := e.policy.Evaluate(flag, time.Now().UTC())
+	log.Printf("[strikes] strike recorded userID=%s decision=%s active=%d uploadsBlocked=%t salesBlocked=%t suspended=%t",
+		userID, decisionID, res.ActiveStrikes, res.UploadsBlocked, res.SalesBlocked, res.SuspendedUntil != nil)
+}
+
+// RemoveStrike drops the strike recorded for decisionID.
+func (e *StrikeEnforcer) RemoveStrike(ctx context.Context, userID, decisionID string) error {
+	if e == nil || e.flags == nil || userID == "" || decisionID == "" {
+		return nil
+	}
+	removed, err := e.flags.RemoveStrike(ctx, userID, decisionID)
+	if err != nil {
+		return err
+	}
+	log.Printf("[strikes] strike removed userID=%s decision=%s removed=%t", userID, decisionID, removed)
+	return nil
+}
+
+// Restrictions returns what the user's active strikes block.
+func (e *StrikeEnforcer) Restrictions(userID string) (*models.UserRestrictions, error) {
+	if e == nil || e.flags == nil || userID == "" {
+		return &models.UserRestrictions{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	flag, err := e.flags.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return e.policy.Evaluate(flag, time.Now().UTC()), nil
+}
+
+// Allow returns ErrUploadsBlocked, ErrSalesBlocked or ErrAccountSuspended if the
+// user may not take the action guarded by code (models.Restriction*). Lookup
+// failures are logged and allowed so an outage doesn't lock everyone out.
+func (e *StrikeEnforcer) Allow(userID, code string) error {
+	res, err := e.Restrictions(userID)
+	if err != nil {
+		log.Printf("[strikes] restriction lookup failed userID=%s err=%v", userID, err)
+		return nil
+	}
+	switch res.Blocking(code) {
+	case models.RestrictionUploadsBlocked:
+		return ErrUploadsBlocked
+	case models.RestrictionSalesBlocked:
+		return ErrSalesBlocked
+	case models.RestrictionAccountSuspended:
+		return ErrAccountSuspended
+	}
+	return nil
+}
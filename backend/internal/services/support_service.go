@@ -0,0 +1,48 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrSupportTicketNotFound = errors.New("support ticket not found")
+	ErrSupportBadInput       = errors.New("bad input")
+)
+
+// SupportTicketService stores support requests and their replies.
+type SupportTicketService interface {
+	// CreateTicket stores a new open ticket and returns the lookup secret. Only a hash of
+	// the secret is kept, so it must be emailed to the submitter right away.
+	CreateTicket(t *models.SupportTicket) (secret string, err error)
+	// GetTicketWithSecret returns ErrSupportTicketNotFound for unknown tickets and wrong
+	// secrets alike, so ticket numbers can't be probed.
+	GetTicketWithSecret(ticketID, secret string) (*models.SupportTicket, error)
+	GetTicket(ticketID string) (*models.SupportTicket, error)
+	// ListTickets returns tickets in the given status (empty means any), newest first.
+	ListTickets(status string, limit int) ([]*models.SupportTicket, error)
+	AddReply(ticketID, adminID string, req *models.ReplySupportTicketRequest) (*models.SupportTicket, error)
+	UpdateStatus(ticketID, status string) (*models.SupportTicket, error)
+}
+
+func newSupportSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashSupportSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func supportSecretMatches(secret, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashSupportSecret(secret)), []byte(hash)) == 1
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Text moderation categories reported by the built-in rules.
+const (
+	TextCategoryProfanity   = "profanity"
+	TextCategorySlur        = "slur"
+	TextCategoryPhone       = "phone"
+	TextCategoryPaymentScam = "payment_scam"
+)
+
+// TextMatch is a flagged span of text. Start and End are byte offsets into the
+// classified string.
+type TextMatch struct {
+	Category string `json:"category"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+}
+
+// TextClassifier flags problem spans in user-written text (titles, descriptions,
+// bios). An empty result means nothing was found.
+type TextClassifier interface {
+	Classify(ctx context.Context, text string) ([]TextMatch, error)
+}
+
+// TextClassifierFunc adapts a function to TextClassifier.
+type TextClassifierFunc func(ctx context.Context, text string) ([]TextMatch, error)
+
+func (f TextClassifierFunc) Classify(ctx context.Context, text string) ([]TextMatch, error) {
+	return f(ctx, text)
+}
+
+// NewTextClassifier picks a classifier by provider name: "rules" (default; the
+// word lists and patterns in policy) or "http" (a model server at endpoint; see
+// HTTPTextClassifier).
+func NewTextClassifier(provider, endpoint string, policy *TextPolicy) (TextClassifier, error) {
+	switch provider {
+	case "rules", "":
+		return NewRulesTextClassifier(policy)
+	case "http":
+		if endpoint == "" {
+			return nil, fmt.Errorf("text classifier: http provider needs an endpoint")
+		}
+		return NewHTTPTextClassifier(endpoint), nil
+	default:
+		return nil, fmt.Errorf("text classifier: unknown provider %q", provider)
+	}
+}
+
+// RulesTextClassifier matches whole words and regular expressions per category.
+// Words are case-insensitive and only match on word boundaries, so "Dickens"
+// doesn't trip a rule for "dick".
+type RulesTextClassifier struct {
+	rules []textRule
+}
+
+type textRule struct {
+	category string
+	re       *regexp.Regexp
+}
+
+func NewRulesTextClassifier(policy *TextPolicy) (*RulesTextClassifier, error) {
+	if policy == nil {
+		policy = DefaultTextPolicy()
+	}
+
+	c := &RulesTextClassifier{}
+	for _, category := range sortedKeys(policy.Words) {
+		words := make([]string, 0, len(policy.Words[category]))
+		for _, w := range policy.Words[category] {
+			if w = strings.TrimSpace(w); w != "" {
+				words = append(words, regexp.QuoteMeta(w))
+			}
+		}
+		if len(words) == 0 {
+			continue
+		}
+		re := regexp.MustCompile(`(?i)\b(?:` + strings.Join(words, "|") + `)\b`)
+		c.rules = append(c.rules, textRule{category: category, re: re})
+	}
+	for _, category := range sortedKeys(policy.Patterns) {
+		for _, pattern := range policy.Patterns[category] {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("text policy: %s pattern %q: %w", category, pattern, err)
+			}
+			c.rules = append(c.rules, textRule{category: category, re: re})
+		}
+	}
+	return c, nil
+}
+
+func (c *RulesTextClassifier) Classify(ctx context.Context, text string) ([]TextMatch, error) {
+	var out []TextMatch
+	for _, rule := range c.rules {
+		for _, loc := range rule.re.FindAllStringIndex(text, -1) {
+			out = append(out, TextMatch{Category: rule.category, Start: loc[0], End: loc[1]})
+		}
+	}
+	return out, nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+// Text moderation outcomes, from mildest to harshest. Review and reject share the
+// image outcome names.
+const (
+	TextModerationAllow = "allow"
+	TextModerationMask  = "mask"
+)
+
+var textOutcomeRank = map[string]int{
+	TextModerationAllow: 0,
+	TextModerationMask:  1,
+	ModerationReview:    2,
+	ModerationReject:    3,
+}
+
+// TextPolicy maps text categories to outcomes and holds the built-in rules
+// engine's word lists and patterns. Categories without an outcome go to review.
+type TextPolicy struct {
+	Version  string              `json:"version,omitempty"`
+	Outcomes map[string]string   `json:"outcomes,omitempty"`
+	Words    map[string][]string `json:"words,omitempty"`
+	Patterns map[string][]string `json:"patterns,omitempty"`
+}
+
+// DefaultTextPolicy masks profanity and phone numbers (buyers should message in
+// the app), sends likely off-platform payment scams to review and rejects slurs.
+func DefaultTextPolicy() *TextPolicy {
+	return &TextPolicy{
+		Version: "builtin-1",
+		Outcomes: map[string]string{
+			TextCategoryProfanity:   TextModerationMask,
+			TextCategoryPhone:       TextModerationMask,
+			TextCategoryPaymentScam: ModerationReview,
+			TextCategorySlur:        ModerationReject,
+		},
+		Words: map[string][]string{
+			TextCategoryProfanity: {
+				"fuck", "fucks", "fucking", "fucked", "fucker", "motherfucker",
+				"shit", "shits", "shitty", "bullshit",
+				"bitch", "bitches", "asshole", "assholes", "dickhead",
+				"cunt", "cunts", "twat", "wanker",
+			},
+			TextCategorySlur: {
+				"nigger", "niggers", "nigga", "niggas",
+				"faggot", "faggots", "kike", "kikes",
+				"wetback", "wetbacks", "tranny", "trannies",
+			},
+		},
+		Patterns: map[string][]string{
+			// US-style numbers: 555-123-4567, (555) 123 4567, +1 555.123.4567.
+			TextCategoryPhone: {
+				`(?:\+?1[\s.-]?)?\(?\b\d{3}\)?[\s.-]?\d{3}[\s.-]?\d{4}\b`,
+			},
+			TextCategoryPaymentScam: {
+				`(?i)\b(?:western\s+union|money\s*gram|wire\s+transfer|cashier'?s\s+check)\b`,
+				`(?i)\bpay(?:ment)?\s+(?:by|with|via|in)\s+(?:gift\s*cards?|crypto|bitcoin|btc|usdt)\b`,
+				`(?i)\b(?:send|pay)\s+(?:a\s+|the\s+|your\s+)?deposit\b`,
+				`(?i)\b(?:whats\s?app|telegram)\b`,
+				`(?i)\bfriends\s+(?:and|&)\s+family\b`,
+			},
+		},
+	}
+}
+
+// LoadTextPolicy reads a JSON text policy. An empty path returns the defaults;
+// otherwise each outcome, word list and pattern list in the file replaces the
+// default for its category. A file without a version is identified by its hash.
+func LoadTextPolicy(path string) (*TextPolicy, error) {
+	p := DefaultTextPolicy()
+	if path == "" {
+		return p, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("text policy: %w", err)
+	}
+	var file TextPolicy
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("text policy: %w", err)
+	}
+	for category, outcome := range file.Outcomes {
+		if _, ok := textOutcomeRank[outcome]; !ok {
+			return nil, fmt.Errorf("text policy: invalid outcome %q for %s (want allow, mask, review or reject)", outcome, category)
+		}
+		p.Outcomes[category] = outcome
+	}
+	for category, words := range file.Words {
+		p.Words[category] = words
+	}
+	for category, patterns := range file.Patterns {
+		p.Patterns[category] = patterns
+	}
+	p.Version = file.Version
+	if p.Version == "" {
+		sum := sha256.Sum256(raw)
+		p.Version = "sha256:" + hex.EncodeToString(sum[:6])
+	}
+	return p, nil
+}
+
+func (p *TextPolicy) outcome(category string) string {
+	if outcome, ok := p.Outcomes[category]; ok {
+		return outcome
+	}
+	return ModerationReview
+}
+
+// TextDecision is the result of checking a set of fields. Rule names the field and
+// category behind the outcome (e.g. "description:payment_scam"); Rejected holds a
+// message per rejected field for the validation response.
+type TextDecision struct {
+	Outcome  string
+	Rule     string
+	Scores   map[string]string
+	Rejected map[string]string
+}
+
+// Held reports whether the content is hidden until a reviewer decides.
+func (d *TextDecision) Held() bool {
+	return d != nil && d.Outcome == ModerationReview
+}
+
+// TextModerationService checks user-written text with a TextClassifier and applies
+// the text policy: masking spans in place, holding content for review, or
+// rejecting it with a strike.
+type TextModerationService struct {
+	classifier TextClassifier
+	policy     *TextPolicy
+	runs       ModerationRunService
+	queue      ModerationQueueService
+	strikes    *StrikeEnforcer
+	sales      SalesService
+	profiles   *MongoProfileService
+	reviews    ReviewService
+}
+
+// NewTextModerationService wires text moderation. policy may be nil to use
+// DefaultTextPolicy; runs and queue may be nil to skip the audit trail and review
+// queue.
+func NewTextModerationService(classifier TextClassifier, policy *TextPolicy, runs ModerationRunService, queue ModerationQueueService, strikes *StrikeEnforcer, sales SalesService, profiles *MongoProfileService, reviews ReviewService) *TextModerationService {
+	if policy == nil {
+		policy = DefaultTextPolicy()
+	}
+	return &TextModerationService{
+		classifier: classifier,
+		policy:     policy,
+		runs:       runs,
+		queue:      queue,
+		strikes:    strikes,
+		sales:      sales,
+		profiles:   profiles,
+		reviews:    reviews,
+	}
+}
+
+// Check classifies each field (nil pointers and empty strings are skipped) and
+// masks matched spans in place unless the text is rejected outright. A nil service
+// allows everything.
+func (t *TextModerationService) Check(ctx context.Context, fields map[string]*string) (*TextDecision, error) {
+	d := &TextDecision{Outcome: TextModerationAllow}
+	if t == nil || t.classifier == nil {
+		return d, nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for name, text := range fields {
+		if text != nil && strings.TrimSpace(*text) != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	masks := make(map[string][]TextMatch)
+	counts := make(map[string]int)
+	for _, name := range names {
+		matches, err := t.classifier.Classify(ctx, *fields[name])
+		if err != nil {
+			return nil, fmt.Errorf("text moderation: classify %s: %w", name, err)
+		}
+		for _, m := range matches {
+			outcome := t.policy.outcome(m.Category)
+			counts[name+":"+m.Category]++
+			if textOutcomeRank[outcome] > textOutcomeRank[d.Outcome] {
+				d.Outcome = outcome
+				d.Rule = name + ":" + m.Category
+			}
+			switch outcome {
+			case TextModerationMask:
+				masks[name] = append(masks[name], m)
+			case ModerationReject:
+				if d.Rejected == nil {
+					d.Rejected = make(map[string]string)
+				}
+				d.Rejected[name] = "Contains language that violates community guidelines"
+			}
+		}
+	}
+
+	if len(counts) > 0 {
+		d.Scores = make(map[string]string, len(counts))
+		for key, n := range counts {
+			d.Scores[key] = strconv.Itoa(n)
+		}
+	}
+	if d.Outcome != ModerationReject {
+		for name, matches := range masks {
+			*fields[name] = maskText(*fields[name], matches)
+		}
+	}
+	return d, nil
+}
+
+// Apply carries out a decision about content owned by userID: every outcome but
+// allow is recorded as a moderation run, rejections add a strike, and content held
+// for review is hidden and queued. targetID may be empty for content that was never
+// created. It returns the decision ID, if any. Failures are logged.
+func (t *TextModerationService) Apply(ctx context.Context, d *TextDecision, userID, targetType, targetID string) string {
+	if t == nil || d == nil || d.Outcome == TextModerationAllow {
+		return ""
+	}
+
+	now := time.Now().UTC()
+	run := newModerationRun(targetType, targetID, "", userID, models.ModerationRunText)
+	run.Scores = d.Scores
+	run.PolicyVersion = t.policy.Version
+	run.Outcome = d.Outcome
+	run.Rule = d.Rule
+	run.StartedAt = now
+	run.CompletedAt = now
+	if t.runs != nil {
+		if err := t.runs.Record(run); err != nil {
+			log.Printf("[text-moderation] record run failed target=%s/%s err=%v", targetType, targetID, err)
+		}
+	}
+	log.Printf("[text-moderation] %s %s/%s user=%s rule=%s decision=%s", d.Outcome, targetType, targetID, userID, d.Rule, run.ID)
+
+	switch d.Outcome {
+	case ModerationReject:
+		t.strikes.AddStrike(ctx, userID, d.Rule, run.ID)
+	case ModerationReview:
+		t.holdForReview(ctx, d, userID, targetType, targetID)
+	}
+	return run.ID
+}
+
+func (t *TextModerationService) holdForReview(ctx context.Context, d *TextDecision, userID, targetType, targetID string) {
+	if targetID == "" {
+		return
+	}
+	var err error
+	switch targetType {
+	case models.ModerationTargetSale:
+		err = t.sales.SetHidden(targetID, true)
+	case models.ModerationTargetItem:
+		saleID, itemID, _ := models.SplitItemTargetID(targetID)
+		err = t.sales.SetItemHidden(saleID, itemID, true)
+	case models.ModerationTargetProfile:
+		err = t.profiles.SetHidden(ctx, targetID, true)
+	case models.ModerationTargetReview:
+		err = t.reviews.SetHidden(targetID, true)
+	case models.ModerationTargetReviewReply:
+		err = t.reviews.SetReplyHidden(targetID, true)
+	}
+	if err != nil {
+		log.Printf("[text-moderation] hide %s/%s failed: %v", targetType, targetID, err)
+		return
+	}
+	if t.queue == nil {
+		return
+	}
+	if _, err := t.queue.Enqueue(&models.ModerationQueueItem{
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     userID,
+		Source:     models.ModerationSourceText,
+		Rule:       d.Rule,
+	}); err != nil {
+		log.Printf("[text-moderation] enqueue %s/%s failed: %v", targetType, targetID, err)
+	}
+}
+
+// maskText replaces each matched span with asterisks, one per character.
+func maskText(text string, matches []TextMatch) string {
+	covered := make([]bool, len(text))
+	for _, m := range matches {
+		for i := m.Start; i < m.End && i < len(covered); i++ {
+			covered[i] = true
+		}
+	}
+	var b strings.Builder
+	for i, r := range text {
+		if covered[i] {
+			b.WriteByte('*')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// TurnstileVerifier verifies Cloudflare Turnstile tokens. When Action is set the token
+// must have been issued for that widget action.
+type TurnstileVerifier struct {
+	Secret     string
+	Action     string
+	HTTPClient *http.Client
+	Endpoint   string
+}
+
+func NewTurnstileVerifier(secret string, action string) *TurnstileVerifier {
+	return &TurnstileVerifier{
+		Secret:   secret,
+		Action:   action,
+		Endpoint: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+		HTTPClient: &http.Client{
+			Timeout: 8 * time.Second,
+		},
+	}
+}
+
+func (v *TurnstileVerifier) Verify(ctx context.Context, token string, remoteIP string) (bool, string, error) {
+	if v == nil {
+		return false, "verifier_not_configured", nil
+	}
+	out, reason, err := siteVerify(ctx, v.HTTPClient, v.Endpoint, v.Secret, token, remoteIP)
+	if err != nil || reason != "" {
+		return false, reason, err
+	}
+	if v.Action != "" && out.Action != v.Action {
+		return false, "action_mismatch", nil
+	}
+	return true, "", nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/rummage/backend/internal/models"
+)
+
+var (
+	ErrWantedNotFound = errors.New("wanted post not found")
+	ErrWantedLimit    = errors.New("wanted post limit reached")
+	ErrWantedBadInput = errors.New("bad input")
+)
+
+// maxWantedPostsPerUser caps how many open wanted posts one buyer can have.
+const maxWantedPostsPerUser = 25
+
+// WantedService is used by handlers; production uses Mongo-backed implementation.
+type WantedService interface {
+	CreateWanted(userID string, req *models.CreateWantedRequest) (*models.WantedPost, error)
+	ListWanted(userID string) ([]*models.WantedPost, error)
+	DeleteWanted(userID, wantedID string) error
+	// ListNearby returns other users' wanted posts whose area covers the given point,
+	// so sellers can see what buyers near them are looking for.
+	ListNearby(userID string, lat, lng float64, limit int) ([]*models.WantedPost, error)
+	// ListMatches returns matches where the user is either the buyer or the seller,
+	// most recent first.
+	ListMatches(userID string, limit int) ([]*models.WantedMatch, error)
+}
+
+// wantedStopwords are dropped from wanted descriptions before keyword matching.
+var wantedStopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "any": true, "for": true, "good": true,
+	"in": true, "looking": true, "need": true, "of": true, "or": true, "the": true,
+	"to": true, "want": true, "wanted": true, "with": true,
+}
+
+// wantedKeywords splits a wanted description into lowercased words worth matching on.
+func wantedKeywords(description string) []string {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		if len(w) < 3 || wantedStopwords[w] {
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// wantedMatchesItem reports whether an item at the sale satisfies the wanted post. The
+// sale must be within the post's radius, and the category and max price must fit. At
+// least half of the description's keywords must appear in the item's text, which is the
+// same text saved searches match on.
+func wantedMatchesItem(post *models.WantedPost, sale *models.GarageSale, item *models.Item) bool {
+	if !saleMatchesSearch(sale, post.Latitude, post.Longitude, post.RadiusMi, "") {
+		return false
+	}
+	if post.Category != "" && !strings.EqualFold(post.Category, item.Category) {
+		return false
+	}
+	if post.MaxPrice != nil && item.Price > *post.MaxPrice {
+		return false
+	}
+
+	keywords := wantedKeywords(post.Description)
+	if len(keywords) == 0 {
+		// A post with only a category still matches on category alone.
+		return post.Category != ""
+	}
+	text := itemSearchText(item)
+	hits := 0
+	for _, k := range keywords {
+		if strings.Contains(text, k) {
+			hits++
+		}
+	}
+	return hits*2 >= len(keywords)
+}